@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -11,6 +14,8 @@ import (
 
 func addCmd() *cobra.Command {
 	var name string
+	var recursive bool
+	var depth int
 
 	cmd := &cobra.Command{
 		Use:   "add [path]",
@@ -18,10 +23,23 @@ func addCmd() *cobra.Command {
 		Long: `Register a directory containing tools with tctl.
 If no path is given, registers the current directory.
 
+A quoted glob registers every matching directory as its own source.
+
+With --recursive, registers each subdirectory of the path that contains
+at least one tool as its own source, instead of the path itself, so
+'list' groups a monorepo's tools by project and --source picks one out.
+Subdirectories one level down are considered, or exactly --depth levels
+down. Directories that scans skip (.git, node_modules, virtualenvs, ...)
+are left out. Sources are named after the subdirectory's path below the
+given one, with dashes for slashes (project-tools).
+
 Examples:
   tctl add                      # Register current directory
   tctl add ./tools              # Register ./tools
-  tctl add ~/scripts -n scripts # Register with custom name`,
+  tctl add ~/scripts -n scripts # Register with custom name
+  tctl add '~/projects/*/tools' # Register each project's tools/
+  tctl add ~/monorepo --recursive          # Each project with tools
+  tctl add ~/monorepo --recursive --depth 2  # Each project/<dir> with tools`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			path := "."
@@ -34,6 +52,30 @@ Examples:
 				return err
 			}
 
+			if cmd.Flags().Changed("depth") && !recursive {
+				return fmt.Errorf("--depth needs --recursive")
+			}
+			if depth < 1 {
+				return fmt.Errorf("--depth must be at least 1")
+			}
+
+			if strings.ContainsAny(path, "*?[") {
+				if name != "" {
+					return fmt.Errorf("--name can't be used with a glob")
+				}
+				if recursive {
+					return fmt.Errorf("--recursive can't be used with a glob")
+				}
+				return addSourceGlob(cfg, path)
+			}
+
+			if recursive {
+				if name != "" {
+					return fmt.Errorf("--name can't be used with --recursive; sources are named after their subdirectories")
+				}
+				return addSourcesRecursive(cfg, path, depth)
+			}
+
 			if err := cfg.AddSource(path, name); err != nil {
 				return err
 			}
@@ -45,7 +87,7 @@ Examples:
 			fmt.Printf("  Name: %s\n", newSource.Name)
 
 			// Scan to show what was found
-			registry, err := scanner.ScanDirectory(newSource.Path)
+			registry, err := scanner.ScanDirectory(newSource.Dir())
 			if err == nil {
 				tools := registry.All()
 				fmt.Printf("  Found %d tools\n", len(tools))
@@ -58,9 +100,157 @@ Examples:
 	}
 
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Custom name for this source")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Register each subdirectory that contains tools as its own source")
+	cmd.Flags().IntVar(&depth, "depth", 1, "How many levels down --recursive looks for subdirectories")
 	return cmd
 }
 
+// addSourceGlob registers every directory matching pattern. Matches that
+// are files or already registered are skipped.
+func addSourceGlob(cfg *config.Global, pattern string) error {
+	expanded, err := config.ExpandPath(pattern)
+	if err != nil {
+		return err
+	}
+
+	matches, err := filepath.Glob(expanded)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("no directories match %s", pattern)
+	}
+
+	added := 0
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || !info.IsDir() {
+			fmt.Printf("  ⚠ Skipped (not a directory): %s\n", match)
+			continue
+		}
+		if isRegistered(cfg, match) {
+			fmt.Printf("  - Already registered: %s\n", match)
+			continue
+		}
+
+		if err := cfg.AddSource(match, globSourceName(cfg, match)); err != nil {
+			return err
+		}
+		newSource := cfg.Sources.Sources[len(cfg.Sources.Sources)-1]
+		fmt.Printf("  ✓ Registered: %s (%s)\n", newSource.Path, newSource.Name)
+		added++
+	}
+
+	fmt.Println()
+	fmt.Printf("Added %d of %d matches.\n", added, len(matches))
+	if added > 0 {
+		fmt.Println("Run 'tctl sync' to rebuild the tool cache.")
+	}
+	return nil
+}
+
+// addSourcesRecursive registers each directory exactly depth levels
+// below path that contains at least one tool. Directories that are
+// already registered are skipped.
+func addSourcesRecursive(cfg *config.Global, path string, depth int) error {
+	root, err := config.ExpandPath(path)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return fmt.Errorf("path is not a directory: %s", root)
+	}
+
+	dirs := subdirsAtDepth(root, depth)
+	added, empty := 0, 0
+	for _, dir := range dirs {
+		if isRegistered(cfg, dir) {
+			fmt.Printf("  - Already registered: %s\n", dir)
+			continue
+		}
+
+		registry, err := scanner.ScanDirectory(dir)
+		if err != nil {
+			return err
+		}
+		n := len(registry.All())
+		if n == 0 {
+			empty++
+			continue
+		}
+
+		if err := cfg.AddSource(dir, recursiveSourceName(cfg, root, dir)); err != nil {
+			return err
+		}
+		newSource := cfg.Sources.Sources[len(cfg.Sources.Sources)-1]
+		fmt.Printf("  ✓ Registered: %s (%s, %d tools)\n", newSource.Path, newSource.Name, n)
+		added++
+	}
+
+	if len(dirs) > 0 {
+		fmt.Println()
+	}
+	fmt.Printf("Added %d sources from %d subdirectories of %s", added, len(dirs), root)
+	if empty > 0 {
+		fmt.Printf(" (%d without tools)", empty)
+	}
+	fmt.Println(".")
+	if added > 0 {
+		fmt.Println("Run 'tctl sync' to rebuild the tool cache.")
+	}
+	return nil
+}
+
+// subdirsAtDepth returns the directories exactly depth levels below root,
+// in lexical order, leaving out any that scans skip and everything under
+// them.
+func subdirsAtDepth(root string, depth int) []string {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() || scanner.ShouldSkipDir(entry.Name()) {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		if depth == 1 {
+			dirs = append(dirs, dir)
+		} else {
+			dirs = append(dirs, subdirsAtDepth(dir, depth-1)...)
+		}
+	}
+	return dirs
+}
+
+// recursiveSourceName names a source found by 'tctl add --recursive'
+// after its path below root: project/tools becomes project-tools. The
+// root's own name is prepended when that name is taken.
+func recursiveSourceName(cfg *config.Global, root, dir string) string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		rel = filepath.Base(dir)
+	}
+	name := strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")
+	if cfg.FindSourceByName(name) == nil {
+		return name
+	}
+	return filepath.Base(root) + "-" + name
+}
+
+// globSourceName names a source found by a glob. Globs like
+// ~/projects/*/tools match many directories with the same base name, so
+// the parent directory is prepended when the base name is taken.
+func globSourceName(cfg *config.Global, dir string) string {
+	name := filepath.Base(dir)
+	if cfg.FindSourceByName(name) == nil {
+		return name
+	}
+	return filepath.Base(filepath.Dir(dir)) + "-" + name
+}
+
 func init() {
 	// Ensure config dir exists on first run
 	config.EnsureConfigDir()
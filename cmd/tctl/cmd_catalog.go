@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+func catalogCmd() *cobra.Command {
+	var outputFile string
+	var groupBy string
+
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Generate a Markdown catalog of all tools",
+		Long: `Writes one Markdown document describing every tool: a table of contents
+grouped by @category, source, or language, followed by each tool's page
+as printed by 'tctl show --markdown'. Entries in the table of contents
+link to the tool's section.
+
+Examples:
+  tctl catalog                       # Grouped by category, to stdout
+  tctl catalog --group-by source     # Grouped by source
+  tctl catalog -o docs/tools.md      # Write to a file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			paths := cfg.SourcePaths()
+			if len(paths) == 0 {
+				fmt.Println("No sources registered.")
+				fmt.Println("Register a directory with: tctl add <path>")
+				return nil
+			}
+
+			registry, err := scanner.ScanDirectories(paths)
+			if err != nil {
+				return err
+			}
+
+			var groupOf func(t *tool.Tool) string
+			switch groupBy {
+			case "category":
+				groupOf = func(t *tool.Tool) string { return t.Category }
+			case "source":
+				sourceNames := make(map[string]string)
+				for _, src := range cfg.Sources.Sources {
+					sourceNames[src.Dir()] = src.Name
+				}
+				groupOf = func(t *tool.Tool) string { return sourceNameFor(t.File, sourceNames) }
+			case "language":
+				groupOf = func(t *tool.Tool) string { return t.Language }
+			default:
+				return fmt.Errorf("unknown --group-by value: %s (valid: category, source, language)", groupBy)
+			}
+
+			var buf bytes.Buffer
+			n := writeCatalog(&buf, registry, groupOf)
+
+			if outputFile == "" {
+				_, err = os.Stdout.Write(buf.Bytes())
+				return err
+			}
+			if err := os.WriteFile(outputFile, buf.Bytes(), 0644); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "✓ Wrote catalog of %d tools to %s\n", n, outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write to a file instead of stdout")
+	cmd.Flags().StringVar(&groupBy, "group-by", "category", "Group tools by category, source, or language")
+	return cmd
+}
+
+// writeCatalog writes every tool in registry as one Markdown document,
+// grouped by groupOf, and returns the number of tools written. Tools with
+// an empty group come last, under "Other".
+func writeCatalog(w io.Writer, registry *tool.Registry, groupOf func(*tool.Tool) string) int {
+	groups := make(map[string][]*tool.Tool)
+	var names []string
+	for _, t := range registry.All() {
+		g := groupOf(t)
+		if g != "" && groups[g] == nil {
+			names = append(names, g)
+		}
+		groups[g] = append(groups[g], t)
+	}
+	sort.Strings(names)
+	if len(groups[""]) > 0 {
+		names = append(names, "")
+	}
+	for _, tools := range groups {
+		sort.Slice(tools, func(i, j int) bool {
+			return tools[i].Name < tools[j].Name
+		})
+	}
+
+	total := len(registry.Tools)
+	fmt.Fprintln(w, "# Tool Catalog")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d tools, generated by `tctl catalog`.\n", total)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "## Contents")
+	fmt.Fprintln(w)
+	for _, g := range names {
+		fmt.Fprintf(w, "- **%s**\n", catalogGroupTitle(g))
+		for _, t := range groups[g] {
+			entry := fmt.Sprintf("[%s](#%s)", t.Name, catalogAnchor(t.Name))
+			if t.Description != "" {
+				entry += " - " + t.Description
+			}
+			fmt.Fprintf(w, "  - %s\n", entry)
+		}
+	}
+	fmt.Fprintln(w)
+
+	for _, g := range names {
+		fmt.Fprintf(w, "## %s\n\n", catalogGroupTitle(g))
+		for _, t := range groups[g] {
+			// An explicit anchor doesn't depend on how a renderer turns
+			// headings into ids
+			fmt.Fprintf(w, "<a id=\"%s\"></a>\n\n", catalogAnchor(t.Name))
+			writeToolMarkdown(w, t, registry, 3)
+		}
+	}
+
+	return total
+}
+
+// catalogGroupTitle returns the heading for a catalog group.
+func catalogGroupTitle(group string) string {
+	if group == "" {
+		return "Other"
+	}
+	return group
+}
+
+// catalogAnchor returns the link target of a tool's catalog section.
+func catalogAnchor(name string) string {
+	var b strings.Builder
+	b.WriteString("tool-")
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
+)
+
+func categoriesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "categories",
+		Short: "List tool categories with counts",
+		Long: `Show every @category used by a tool, with the number of tools in it.
+Use 'tctl list --group-by category' to see the tools themselves.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			paths := cfg.SourcePaths()
+			if len(paths) == 0 {
+				fmt.Println("No sources registered.")
+				return nil
+			}
+
+			registry, err := scanner.ScanDirectories(paths)
+			if err != nil {
+				return err
+			}
+
+			counts := make(map[string]int)
+			uncategorized := 0
+			for _, t := range registry.All() {
+				if t.Category == "" {
+					uncategorized++
+					continue
+				}
+				counts[t.Category]++
+			}
+
+			var categories []string
+			for c := range counts {
+				categories = append(categories, c)
+			}
+			sort.Strings(categories)
+
+			fmt.Println()
+			fmt.Println("Categories:")
+			for _, c := range categories {
+				fmt.Printf("  %-24s %d\n", c, counts[c])
+			}
+			if uncategorized > 0 {
+				fmt.Printf("  %-24s %d\n", "(uncategorized)", uncategorized)
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+}
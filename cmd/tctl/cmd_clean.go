@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+)
+
+func cleanCmd() *cobra.Command {
+	var caches, sources, all, yes bool
+
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove tctl caches, sources, or all configuration",
+		Long: `Resets tctl to a clean state. Prints what will be removed and asks for
+confirmation first; pass --yes to skip the prompt.
+
+  --caches    Remove the tool cache (lock files are kept, since removing
+              one could let two processes run the same tool)
+  --sources   Unregister every source, keeping settings and other files
+  --all       Remove the entire config directory
+
+Examples:
+  tctl clean --caches          # Forget the last sync
+  tctl clean --sources --yes   # Unregister all sources without asking
+  tctl clean --all             # Start over`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !caches && !sources && !all {
+				return fmt.Errorf("nothing to clean: pass --caches, --sources, or --all")
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			var remove []string
+			if all {
+				remove = existingPaths(cfg.ConfigDir)
+			} else if caches {
+				remove = existingPaths(filepath.Join(cfg.ConfigDir, config.CacheFile))
+			}
+			clearSources := sources && !all && len(cfg.Sources.Sources) > 0
+
+			if len(remove) == 0 && !clearSources {
+				fmt.Println("Nothing to clean.")
+				return nil
+			}
+
+			fmt.Println("This will:")
+			for _, path := range remove {
+				fmt.Printf("  - remove %s\n", path)
+			}
+			if clearSources {
+				fmt.Printf("  - unregister %d sources\n", len(cfg.Sources.Sources))
+			}
+
+			if !yes && !confirm("Continue?") {
+				fmt.Println("Aborted.")
+				return nil
+			}
+
+			for _, path := range remove {
+				if err := os.RemoveAll(path); err != nil {
+					return err
+				}
+			}
+			if clearSources {
+				cfg.Sources.Sources = nil
+				if err := cfg.Save(); err != nil {
+					return err
+				}
+			}
+
+			fmt.Println("✓ Cleaned")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&caches, "caches", false, "Remove the tool cache (lock files are kept)")
+	cmd.Flags().BoolVar(&sources, "sources", false, "Unregister all sources")
+	cmd.Flags().BoolVar(&all, "all", false, "Remove the entire config directory")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Don't ask for confirmation")
+	return cmd
+}
+
+// existingPaths returns those of paths that exist.
+func existingPaths(paths ...string) []string {
+	var found []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			found = append(found, path)
+		}
+	}
+	return found
+}
+
+// confirm asks a yes/no question on stdin and reports whether the answer
+// was yes. No answer, including end of input, counts as no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+)
+
+func configCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and change global settings",
+		Long: `Read and write global settings stored in settings.yaml.
+
+Examples:
+  tctl config list                          # Show all settings
+  tctl config get default_language          # Show one setting
+  tctl config set default_language python   # Change a setting`,
+	}
+
+	cmd.AddCommand(configListCmd())
+	cmd.AddCommand(configGetCmd())
+	cmd.AddCommand(configSetCmd())
+	return cmd
+}
+
+func configListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show all settings",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			for _, key := range config.SettingKeys {
+				value, err := cfg.Settings.Get(key)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s = %s\n", key, value)
+			}
+			return nil
+		},
+	}
+}
+
+func configGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Show the value of a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			value, err := cfg.Settings.Get(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+}
+
+func configSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change the value of a setting",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.Settings.Set(args[0], args[1]); err != nil {
+				return err
+			}
+			if err := cfg.SaveSettings(); err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ %s = %s\n", args[0], args[1])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+func consumersCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "consumers <artifact-or-tool>",
+		Short: "List the tools that require an artifact",
+		Long: `Lists every tool whose @requires references an artifact, with its file
+and the requirement that matches, including any version constraint. Given a tool name, lists the consumers
+of everything the tool @provides. Run it before changing or removing a
+@provides to see what depends on it.
+
+Requirements match by full or bare name: consumers of "finance/report"
+include tools that require just "report". A bare artifact name also
+covers the namespaced artifacts it resolves to.
+
+Examples:
+  tctl consumers prices         # Who requires prices?
+  tctl consumers fetch-prices   # Who requires anything fetch-prices provides?`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			paths := cfg.SourcePaths()
+			if len(paths) == 0 {
+				fmt.Println("No sources registered.")
+				fmt.Println("Register a directory with: tctl add <path>")
+				return nil
+			}
+
+			registry, err := scanner.ScanDirectories(paths)
+			if err != nil {
+				return err
+			}
+
+			name := args[0]
+			if t := registry.Get(name); t != nil && len(t.Provides) == 0 {
+				fmt.Printf("%s has no @provides, so nothing can require it.\n", name)
+				return nil
+			}
+			artifacts := consumedArtifacts(registry, name)
+
+			// A tool requiring both "report" and "finance/report" is
+			// listed once, with both requirements
+			var consumers []*tool.Tool
+			matched := make(map[*tool.Tool][]string)
+			seen := make(map[string]bool) // tool name + " " + requirement
+			for _, data := range artifacts {
+				for _, t := range registry.Dependents(data) {
+					if matched[t] == nil {
+						consumers = append(consumers, t)
+					}
+					for _, req := range t.MatchingRequires(data) {
+						if key := t.Name + " " + req; !seen[key] {
+							seen[key] = true
+							if c := t.Constraint(req); c != nil {
+								req = c.String()
+							}
+							matched[t] = append(matched[t], req)
+						}
+					}
+				}
+			}
+
+			fmt.Println()
+			fmt.Printf("Consumers of %s:\n", strings.Join(artifacts, ", "))
+			fmt.Println()
+			if len(consumers) == 0 {
+				fmt.Println("  (none)")
+			}
+			for _, t := range consumers {
+				fmt.Printf("  %-24s requires %s\n", t.Name, strings.Join(matched[t], ", "))
+				fmt.Printf("  %-24s %s\n", "", term.Dim(t.File))
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+}
+
+// consumedArtifacts returns the artifacts whose consumers 'tctl consumers
+// name' lists: everything the tool called name provides or, if there is no
+// such tool, name itself plus the namespaced artifacts a bare name resolves
+// to.
+func consumedArtifacts(registry *tool.Registry, name string) []string {
+	if t := registry.Get(name); t != nil {
+		return t.Provides
+	}
+
+	artifacts := []string{name}
+	seen := map[string]bool{name: true}
+	for _, provider := range registry.Providers(name) {
+		for _, p := range provider.Provides {
+			if !seen[p] && tool.ProvidesMatch(p, name) {
+				seen[p] = true
+				artifacts = append(artifacts, p)
+			}
+		}
+	}
+	return artifacts
+}
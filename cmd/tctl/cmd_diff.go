@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/cache"
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// diffSkipFields are Tool fields that change with every edit to a file
+// without changing what the tool is.
+var diffSkipFields = map[string]bool{
+	"tag_lines": true,
+	"doc_start": true,
+	"doc_end":   true,
+}
+
+func diffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff [tool-name]",
+		Short: "Show how tools changed since the last sync",
+		Long: `Compares each tool's metadata as cached by the last 'tctl sync' with a
+fresh scan and prints the fields that changed. Without a tool name, shows
+every tool that was added, removed, or changed.
+
+Examples:
+  tctl diff                  # Everything that changed since 'tctl sync'
+  tctl diff build-report     # Only build-report`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snap, err := cache.Load()
+			if err != nil {
+				return err
+			}
+			if snap == nil {
+				fmt.Println("No tool cache yet. Run 'tctl sync' first.")
+				return nil
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			registry, err := scanner.ScanDirectories(cfg.SourcePaths())
+			if err != nil {
+				return err
+			}
+
+			var names []string
+			if len(args) == 1 {
+				names = args
+			} else {
+				seen := make(map[string]bool)
+				for _, t := range snap.Tools {
+					seen[t.Name] = true
+				}
+				for _, t := range registry.All() {
+					seen[t.Name] = true
+				}
+				for name := range seen {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+			}
+
+			fmt.Printf("Changes since last sync (%s):\n", snap.Synced.Format("2006-01-02 15:04"))
+			changed := 0
+			for _, name := range names {
+				before, after := snap.Get(name), registry.Get(name)
+				switch {
+				case before == nil && after == nil:
+					return fmt.Errorf("unknown tool: %s", name)
+				case before == nil:
+					fmt.Printf("\n%s %s (new)\n", term.Green("+"), name)
+				case after == nil:
+					fmt.Printf("\n%s %s (removed)\n", term.Red("-"), name)
+				default:
+					lines := diffTools(before, after)
+					if len(lines) == 0 {
+						continue
+					}
+					fmt.Printf("\n%s %s\n", term.Yellow("~"), name)
+					for _, line := range lines {
+						fmt.Printf("    %s\n", line)
+					}
+				}
+				changed++
+			}
+
+			if changed == 0 {
+				fmt.Println("  No changes.")
+			}
+			return nil
+		},
+	}
+}
+
+// diffTools describes each field that differs between two versions of a
+// tool, in struct order.
+func diffTools(before, after *tool.Tool) []string {
+	var lines []string
+
+	b := reflect.ValueOf(before).Elem()
+	a := reflect.ValueOf(after).Elem()
+	for i := 0; i < b.NumField(); i++ {
+		name := strings.Split(b.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		if diffSkipFields[name] {
+			continue
+		}
+
+		oldValue, newValue := b.Field(i).Interface(), a.Field(i).Interface()
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		switch oldValue.(type) {
+		case []string, []tool.Requirement, []tool.Example:
+			added, removed := diffLists(listStrings(oldValue), listStrings(newValue))
+			for _, item := range added {
+				lines = append(lines, fmt.Sprintf("%s %s: %s", term.Green("+"), name, item))
+			}
+			for _, item := range removed {
+				lines = append(lines, fmt.Sprintf("%s %s: %s", term.Red("-"), name, item))
+			}
+			if len(added) == 0 && len(removed) == 0 {
+				lines = append(lines, fmt.Sprintf("%s %s: reordered", term.Yellow("~"), name))
+			}
+		case map[string]tool.Arg:
+			lines = append(lines, diffInterface(before.Interface, after.Interface)...)
+		default:
+			lines = append(lines, fmt.Sprintf("%s %s: %s → %s",
+				term.Yellow("~"), name, diffValue(oldValue), diffValue(newValue)))
+		}
+	}
+
+	return lines
+}
+
+// diffInterface describes added, removed, and changed interface arguments.
+func diffInterface(before, after map[string]tool.Arg) []string {
+	var names []string
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		oldArg, hadArg := before[name]
+		newArg, hasArg := after[name]
+		switch {
+		case !hadArg:
+			lines = append(lines, fmt.Sprintf("%s interface: %s", term.Green("+"), name))
+		case !hasArg:
+			lines = append(lines, fmt.Sprintf("%s interface: %s", term.Red("-"), name))
+		case !reflect.DeepEqual(oldArg, newArg):
+			lines = append(lines, fmt.Sprintf("%s interface: %s changed", term.Yellow("~"), name))
+		}
+	}
+	return lines
+}
+
+// listStrings returns the items of a string, requirement, or example list
+// as they are written in tags.
+func listStrings(v interface{}) []string {
+	switch list := v.(type) {
+	case []string:
+		return list
+	case []tool.Requirement:
+		var items []string
+		for _, r := range list {
+			items = append(items, r.String())
+		}
+		return items
+	case []tool.Example:
+		var items []string
+		for _, ex := range list {
+			items = append(items, ex.String())
+		}
+		return items
+	}
+	return nil
+}
+
+// diffLists returns the items only in after and the items only in before.
+func diffLists(before, after []string) (added, removed []string) {
+	inBefore := make(map[string]bool)
+	for _, item := range before {
+		inBefore[item] = true
+	}
+	inAfter := make(map[string]bool)
+	for _, item := range after {
+		inAfter[item] = true
+		if !inBefore[item] {
+			added = append(added, item)
+		}
+	}
+	for _, item := range before {
+		if !inAfter[item] {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
+}
+
+// diffValue formats a scalar field for a diff line.
+func diffValue(v interface{}) string {
+	if reflect.ValueOf(v).IsZero() {
+		return "(none)"
+	}
+	return fmt.Sprintf("%v", v)
+}
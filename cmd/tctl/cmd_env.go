@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/runner"
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+func envCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "env [tool-name]",
+		Short: "Check the Python environment tools run in",
+		Long: `Reports the Python interpreter tools are run with, its version, and
+whether uv and pip are available.
+
+Given a tool, also checks its @runtime requirements, that each
+@requires-cmd program is on PATH, that each @requires-env variable is set
+in the current environment, and whether each @pip package can be
+imported. The tool itself is not run. Exits with status 1 if any of the
+tool's checks fail.
+
+Packages are imported by their lowercased name with "-" as "_", apart
+from a few well-known exceptions such as PyYAML (yaml) and Pillow (PIL).
+Other packages whose module name differs may be reported as missing.
+
+Examples:
+  tctl env                  # Interpreter, uv, and pip
+  tctl env build-report     # ...plus build-report's requirements`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			python := runner.GetRunnerByLanguage("python").(*runner.PythonRunner)
+			command := python.PythonCommand()
+
+			fmt.Println("Python environment:")
+			if command == nil {
+				fmt.Println("  ✗ Interpreter: not found")
+			} else if version, err := python.Version(); err != nil {
+				fmt.Printf("  ✗ Interpreter: %s (%v)\n", strings.Join(command, " "), err)
+			} else {
+				fmt.Printf("  ✓ Interpreter: %s (%s)\n", strings.Join(command, " "), version)
+			}
+
+			if uvPath, err := exec.LookPath("uv"); err == nil {
+				fmt.Printf("  ✓ uv: %s\n", uvPath)
+			} else {
+				fmt.Println("  - uv: not installed")
+			}
+
+			if command != nil && pythonSucceeds(command, "-m", "pip", "--version") {
+				fmt.Println("  ✓ pip: available")
+			} else {
+				fmt.Println("  - pip: not available")
+			}
+
+			if len(args) == 0 {
+				return nil
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			registry, err := scanner.ScanDirectories(cfg.SourcePaths())
+			if err != nil {
+				return err
+			}
+
+			t := registry.Get(args[0])
+			if t == nil {
+				return unknownToolError(args[0], registry)
+			}
+
+			if !checkToolEnv(t, command) {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+}
+
+// checkToolEnv prints the result of checking t's @runtime, @requires-cmd,
+// @requires-env, and @pip requirements and reports whether all of them are
+// met.
+func checkToolEnv(t *tool.Tool, python []string) bool {
+	fmt.Println()
+	fmt.Printf("%s:\n", t.Name)
+
+	if len(t.Runtime) == 0 && len(t.RequiresCmd) == 0 && len(t.RequiresEnv) == 0 && len(t.PipRequires) == 0 {
+		fmt.Println("  No @runtime, @requires-cmd, @requires-env, or @pip requirements declared.")
+		return true
+	}
+
+	ok := true
+
+	if len(t.Runtime) > 0 {
+		var err error
+		if r := runner.GetRunner(t); r == nil {
+			err = &runner.UnsupportedLanguageError{Language: t.Language}
+		} else if checker, isChecker := r.(runner.RuntimeChecker); isChecker {
+			err = checker.CheckRuntime(t)
+		}
+
+		var runtime []string
+		for _, r := range t.Runtime {
+			runtime = append(runtime, r.String())
+		}
+		if err != nil {
+			fmt.Printf("  ✗ %s\n", err)
+			ok = false
+		} else {
+			fmt.Printf("  ✓ Runtime: %s\n", strings.Join(runtime, ", "))
+		}
+	}
+
+	for _, name := range t.RequiresCmd {
+		if path, err := exec.LookPath(name); err == nil {
+			fmt.Printf("  ✓ %s: %s\n", name, path)
+		} else {
+			fmt.Printf("  ✗ missing required command: %s\n", name)
+			ok = false
+		}
+	}
+
+	for _, name := range t.RequiresEnv {
+		if os.Getenv(name) != "" {
+			fmt.Printf("  ✓ $%s: set\n", name)
+		} else {
+			fmt.Printf("  ✗ missing required environment variable: %s\n", name)
+			ok = false
+		}
+	}
+
+	for _, spec := range t.PipRequires {
+		module := pipModuleName(spec)
+		if python == nil {
+			fmt.Printf("  ✗ %s (no Python interpreter)\n", spec)
+			ok = false
+		} else if pythonSucceeds(python, "-c", "import "+module) {
+			fmt.Printf("  ✓ %s\n", spec)
+		} else {
+			fmt.Printf("  ✗ %s (import %s failed)\n", spec, module)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+// pythonSucceeds runs the interpreter command with args, discarding its
+// output, and reports whether it exited successfully.
+func pythonSucceeds(python []string, args ...string) bool {
+	cmdArgs := append(append([]string{}, python[1:]...), args...)
+	return exec.Command(python[0], cmdArgs...).Run() == nil
+}
+
+// pipModules maps well-known packages whose module name differs from the
+// package name, keyed by lowercased package name.
+var pipModules = map[string]string{
+	"beautifulsoup4":  "bs4",
+	"opencv-python":   "cv2",
+	"pillow":          "PIL",
+	"python-dateutil": "dateutil",
+	"python-dotenv":   "dotenv",
+	"pyyaml":          "yaml",
+	"scikit-learn":    "sklearn",
+}
+
+// pipModuleName guesses the module a pip package is imported as.
+func pipModuleName(spec string) string {
+	name := strings.ToLower(tool.PipPackageName(spec))
+	if module, ok := pipModules[name]; ok {
+		return module
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// manifest is the document written by 'tctl export': the registered
+// sources and every tool found in them.
+type manifest struct {
+	Sources []manifestSource `yaml:"sources" json:"sources"`
+	Tools   []manifestTool   `yaml:"tools" json:"tools"`
+}
+
+// manifestSource is a registered source as recorded in a manifest. Path is
+// kept as registered (e.g. "~/tools") so manifests move between machines.
+type manifestSource struct {
+	Name     string `yaml:"name" json:"name"`
+	Path     string `yaml:"path" json:"path"`
+	Enabled  bool   `yaml:"enabled" json:"enabled"`
+	Priority int    `yaml:"priority,omitempty" json:"priority,omitempty"`
+}
+
+// manifestTool is a tool plus the name of the source it was found in.
+type manifestTool struct {
+	tool.Tool `yaml:",inline"`
+	Source    string `yaml:"source" json:"source"`
+}
+
+func exportCmd() *cobra.Command {
+	var format string
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all sources and tools as JSON or YAML",
+		Long: `Scans all registered sources and writes everything tctl knows:
+the sources and each tool's metadata, absolute file path, and source name.
+
+The output can be read back with 'tctl import' on another machine.
+
+Examples:
+  tctl export                         # YAML to stdout
+  tctl export --format json           # JSON to stdout
+  tctl export -o tctl-manifest.yaml   # Write to a file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "yaml" && format != "json" {
+				return fmt.Errorf("unknown --format: %s (valid: yaml, json)", format)
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			registry, err := scanner.ScanDirectories(cfg.SourcePaths())
+			if err != nil {
+				return err
+			}
+
+			m, err := buildManifest(cfg, registry)
+			if err != nil {
+				return err
+			}
+
+			var data []byte
+			if format == "json" {
+				data, err = json.MarshalIndent(m, "", "  ")
+				data = append(data, '\n')
+			} else {
+				data, err = yaml.Marshal(m)
+			}
+			if err != nil {
+				return err
+			}
+
+			if outputFile == "" {
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+			if err := os.WriteFile(outputFile, data, 0644); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "✓ Exported %d sources and %d tools to %s\n", len(m.Sources), len(m.Tools), outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "yaml", "Output format: yaml or json")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write to a file instead of stdout")
+	return cmd
+}
+
+// buildManifest collects the registered sources and the scanned tools,
+// sorted by name.
+func buildManifest(cfg *config.Global, registry *tool.Registry) (*manifest, error) {
+	m := &manifest{
+		Sources: []manifestSource{},
+		Tools:   []manifestTool{},
+	}
+
+	sourceNames := make(map[string]string)
+	for _, src := range cfg.Sources.Sources {
+		sourceNames[src.Dir()] = src.Name
+		m.Sources = append(m.Sources, manifestSource{
+			Name:     src.Name,
+			Path:     src.Path,
+			Enabled:  src.Enabled,
+			Priority: src.Priority,
+		})
+	}
+
+	for _, t := range registry.All() {
+		entry := manifestTool{Tool: *t, Source: sourceNameFor(t.File, sourceNames)}
+		absPath, err := filepath.Abs(t.File)
+		if err != nil {
+			return nil, err
+		}
+		entry.File = absPath
+		m.Tools = append(m.Tools, entry)
+	}
+	sort.Slice(m.Tools, func(i, j int) bool {
+		return m.Tools[i].Name < m.Tools[j].Name
+	})
+
+	return m, nil
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -9,21 +11,116 @@ import (
 
 	"github.com/yourname/tctl/internal/config"
 	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/util"
 	"github.com/yourname/tctl/pkg/tool"
 )
 
+// findFilter restricts find results to tools whose @provides or @requires
+// entries match a pattern: a glob if it contains *, ?, or [, otherwise a
+// substring. Matching is case-insensitive. If languages is set, tools must
+// also be written in one of them.
+type findFilter struct {
+	provides  string
+	requires  string
+	languages []string
+}
+
 func findCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "find <keywords...>",
+	var filter findFilter
+	var useRegex bool
+	var caseSensitive, wholeWord, noStem bool
+	var sortBy string
+	var paging pageFlags
+
+	cmd := &cobra.Command{
+		Use:   "find [keywords...]",
 		Short: "Find tools by keyword",
 		Long: `Search for tools matching the given keywords.
 Searches tool name, description, keywords, and capabilities.
 
+--provides and --requires filter on those fields only. They can be
+combined with each other and with keywords, which then narrow the results.
+--language keeps only tools in the given language.
+
+With --regex, the arguments are joined into one Go regular expression
+that is matched against each tool's name, description, provides, and
+keywords. Matching is case-sensitive unless the pattern starts with (?i).
+Results are listed by name instead of by relevance.
+
+--sort orders results by score (relevance, best first; the default),
+name, or recent (newest tool file first). Ties are broken by name.
+Regex matches aren't scored, so they sort by name or recent.
+
+Keywords match anywhere in a field, ignoring case. --word matches whole
+words only (so "id" no longer matches "liquidity"), and --case-sensitive
+requires exact case. Keywords also match other forms of the same word,
+so "parsing" finds "parses"; --no-stem turns this off.
+
+A quoted phrase, such as "error parse", must appear exactly as written,
+words adjacent and in order, to earn a phrase bonus worth twice a word
+match. Its words still match on their own at the normal weight, so
+tools that mention only some of them rank below tools with the phrase.
+Quote phrases for the shell, or put double quotes inside one argument
+to mix phrases and words: '"error parse" logs'.
+
+Keywords are expanded with synonyms, so k8s also finds kubernetes.
+Synonym matches rank slightly below direct ones. Add your own synonyms
+in synonyms.yaml in the config directory.
+
+The 10 best matches are shown. --page and --page-size page through all
+of them instead.
+
 Examples:
-  tctl find logs           # Find log-related tools
-  tctl find "error parse"  # Find error parsing tools`,
-		Args: cobra.MinimumNArgs(1),
+  tctl find logs                     # Find log-related tools
+  tctl find "error parse"            # Tools with the phrase rank first
+  tctl find '"error parse" logs'     # A phrase plus a separate word
+  tctl find --provides 'price*'      # Tools producing price data
+  tctl find --requires prices        # Tools that consume prices
+  tctl find --requires prices report # ...that also match "report"
+  tctl find --regex '^fetch-.*-prices$'
+  tctl find id --word                # "id", but not "liquidity"
+  tctl find report --language python # Only Python tools
+  tctl find prices --sort recent     # Most recently changed first
+  tctl find data --page 2            # Matches 21-40`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && filter.provides == "" && filter.requires == "" {
+				return fmt.Errorf("give keywords, --provides, or --requires")
+			}
+
+			var re *regexp.Regexp
+			if useRegex {
+				if caseSensitive || wholeWord {
+					return fmt.Errorf("--word and --case-sensitive don't apply to --regex; use \\b and (?i) in the pattern")
+				}
+				if noStem {
+					return fmt.Errorf("--no-stem doesn't apply to --regex")
+				}
+				if len(args) == 0 {
+					return fmt.Errorf("--regex needs a pattern")
+				}
+				var err error
+				if re, err = regexp.Compile(strings.Join(args, " ")); err != nil {
+					return fmt.Errorf("invalid --regex: %w", err)
+				}
+			}
+
+			if sortBy == "" {
+				sortBy = "score"
+				if useRegex {
+					sortBy = "name"
+				}
+			}
+			allowed := []string{"score", "name", "recent"}
+			if useRegex {
+				allowed = allowed[1:]
+			}
+			order, err := newToolOrder(sortBy, allowed...)
+			if err != nil {
+				return err
+			}
+
+			warnUnknownLanguages(filter.languages)
+
 			cfg, err := config.Load()
 			if err != nil {
 				return err
@@ -40,13 +137,19 @@ Examples:
 				return err
 			}
 
-			searchTerms := strings.ToLower(strings.Join(args, " "))
-			tools := registry.All()
+			phrases, words := util.SplitPhrases(args)
+			terms := util.ExpandTerms(words, cfg.Synonyms)
 
-			matches := findToolMatches(tools, searchTerms)
+			var matches []toolMatch
+			if re != nil {
+				matches = findRegexMatches(registry.All(), re, filter)
+			} else {
+				matches = findToolMatches(registry, terms, phrases, filter, util.NewMatcher(caseSensitive, wholeWord, !noStem))
+			}
+			query := describeFindQuery(args, filter)
 
 			if len(matches) == 0 {
-				fmt.Printf("No tools found matching: %s\n", strings.Join(args, " "))
+				fmt.Printf("No tools found matching: %s\n", query)
 				fmt.Println()
 				fmt.Println("Try:")
 				fmt.Println("  tctl what     - See all keywords")
@@ -54,18 +157,29 @@ Examples:
 				return nil
 			}
 
-			// Sort by score (best matches first)
 			sort.Slice(matches, func(i, j int) bool {
-				return matches[i].score > matches[j].score
+				return order.less(matches[i].tool, matches[j].tool, matches[i].score, matches[j].score)
 			})
 
 			fmt.Println()
-			fmt.Printf("# Tools matching '%s'\n", strings.Join(args, " "))
+			fmt.Printf("# Tools matching '%s'\n", query)
 			fmt.Println()
 
+			if paging.enabled() {
+				start, end, pages, err := paging.bounds(len(matches))
+				if err != nil {
+					return err
+				}
+				for _, m := range matches[start:end] {
+					printToolMatch(m)
+				}
+				paging.printPageFooter(pages)
+				return nil
+			}
+
 			for i, m := range matches {
 				if i >= 10 {
-					fmt.Printf("... and %d more matches\n", len(matches)-10)
+					fmt.Printf("... and %d more matches (see them with --page)\n", len(matches)-10)
 					break
 				}
 				printToolMatch(m)
@@ -74,6 +188,99 @@ Examples:
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&filter.provides, "provides", "", "Only tools whose @provides match this pattern")
+	cmd.Flags().StringVar(&filter.requires, "requires", "", "Only tools whose @requires match this pattern")
+	cmd.Flags().StringArrayVar(&filter.languages, "language", nil, "Only tools in this language (repeatable)")
+	cmd.Flags().BoolVar(&useRegex, "regex", false, "Treat the arguments as a regular expression")
+	cmd.Flags().BoolVar(&wholeWord, "word", false, "Match keywords as whole words only")
+	cmd.Flags().BoolVar(&caseSensitive, "case-sensitive", false, "Match keywords with exact case")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "Order results by score, name, or recent (default score)")
+	cmd.Flags().BoolVar(&noStem, "no-stem", false, "Don't match other forms of a keyword (parse, parsing, parsed)")
+	addPageFlags(cmd, &paging)
+	return cmd
+}
+
+// describeFindQuery renders the search for headings, e.g.
+// "report --requires prices".
+func describeFindQuery(args []string, filter findFilter) string {
+	parts := append([]string{}, args...)
+	if filter.provides != "" {
+		parts = append(parts, "--provides "+filter.provides)
+	}
+	if filter.requires != "" {
+		parts = append(parts, "--requires "+filter.requires)
+	}
+	for _, lang := range filter.languages {
+		parts = append(parts, "--language "+lang)
+	}
+	return strings.Join(parts, " ")
+}
+
+// findRegexMatches returns the tools whose name, description, provides,
+// or keywords match re and that pass filter. Matches are not scored.
+func findRegexMatches(tools []*tool.Tool, re *regexp.Regexp, filter findFilter) []toolMatch {
+	var matches []toolMatch
+	for _, t := range tools {
+		if !hasLanguage(t, filter.languages) {
+			continue
+		}
+		if filter.provides != "" {
+			if _, ok := matchField(filter.provides, t.Provides); !ok {
+				continue
+			}
+		}
+		if filter.requires != "" {
+			if _, ok := matchField(filter.requires, t.Requires); !ok {
+				continue
+			}
+		}
+
+		var reasons []string
+		if re.MatchString(t.Name) {
+			reasons = append(reasons, "name matches")
+		}
+		if re.MatchString(t.Description) {
+			reasons = append(reasons, "description matches")
+		}
+		for _, p := range t.Provides {
+			if re.MatchString(p) {
+				reasons = append(reasons, fmt.Sprintf("provides '%s'", p))
+			}
+		}
+		for _, kw := range t.Keywords {
+			if re.MatchString(kw) {
+				reasons = append(reasons, fmt.Sprintf("keyword '%s'", kw))
+			}
+		}
+
+		if len(reasons) > 0 {
+			matches = append(matches, toolMatch{t, 1, reasons})
+		}
+	}
+	return matches
+}
+
+// matchFieldPattern reports whether value matches a --provides or
+// --requires pattern.
+func matchFieldPattern(pattern, value string) bool {
+	pattern = strings.ToLower(pattern)
+	value = strings.ToLower(value)
+	if strings.ContainsAny(pattern, "*?[") {
+		ok, _ := path.Match(pattern, value)
+		return ok
+	}
+	return strings.Contains(value, pattern)
+}
+
+// matchField returns the first entry of values that matches pattern.
+func matchField(pattern string, values []string) (string, bool) {
+	for _, v := range values {
+		if matchFieldPattern(pattern, v) {
+			return v, true
+		}
+	}
+	return "", false
 }
 
 type toolMatch struct {
@@ -82,65 +289,137 @@ type toolMatch struct {
 	reasons []string
 }
 
-func findToolMatches(tools []*tool.Tool, searchTerms string) []toolMatch {
+// findToolMatches scores the tools that match terms or pass filter. Each
+// of phrases found as written adds a bonus of twice the field's weight
+// for a single word.
+func findToolMatches(registry *tool.Registry, terms []util.SearchTerm, phrases []string, filter findFilter, matcher *util.Matcher) []toolMatch {
 	var matches []toolMatch
-	terms := strings.Fields(searchTerms)
 
-	for _, t := range tools {
+	// Many tools share keywords, so match each distinct keyword once and
+	// credit the tools indexed under it
+	type keywordHit struct {
+		keyword string
+		term    util.SearchTerm
+	}
+	keywordHits := make(map[*tool.Tool][]keywordHit)
+	for _, kw := range registry.Keywords() {
+		for _, term := range terms {
+			if matcher.Contains(kw, term.Text) || matcher.Contains(term.Text, kw) {
+				for _, t := range registry.WithKeyword(kw) {
+					keywordHits[t] = append(keywordHits[t], keywordHit{kw, term})
+				}
+			}
+		}
+	}
+
+	for _, t := range registry.All() {
+		if !hasLanguage(t, filter.languages) {
+			continue
+		}
+
 		var reasons []string
 		score := 0
 
+		// Structural filters (weighted above any free-text match)
+		structural := 0
+		if filter.provides != "" {
+			p, ok := matchField(filter.provides, t.Provides)
+			if !ok {
+				continue
+			}
+			structural += 15
+			reasons = append(reasons, fmt.Sprintf("provides '%s'", p))
+		}
+		if filter.requires != "" {
+			r, ok := matchField(filter.requires, t.Requires)
+			if !ok {
+				continue
+			}
+			structural += 15
+			reasons = append(reasons, fmt.Sprintf("requires '%s'", r))
+		}
+
 		// Check tool name (highest weight)
-		nameLower := strings.ToLower(t.Name)
 		for _, term := range terms {
-			if strings.Contains(nameLower, term) {
-				score += 10
-				reasons = append(reasons, fmt.Sprintf("name contains '%s'", term))
+			if matcher.Contains(t.Name, term.Text) {
+				score += term.Score(10)
+				reasons = append(reasons, term.Reason(fmt.Sprintf("name contains '%s'", term.Text)))
 			}
 		}
 
 		// Check description
-		descLower := strings.ToLower(t.Description)
 		for _, term := range terms {
-			if strings.Contains(descLower, term) {
-				score += 5
-				reasons = append(reasons, fmt.Sprintf("description contains '%s'", term))
+			if matcher.Contains(t.Description, term.Text) {
+				score += term.Score(5)
+				reasons = append(reasons, term.Reason(fmt.Sprintf("description contains '%s'", term.Text)))
 			}
 		}
 
 		// Check keywords
-		for _, kw := range t.Keywords {
-			kwLower := strings.ToLower(kw)
-			for _, term := range terms {
-				if strings.Contains(kwLower, term) || strings.Contains(term, kwLower) {
-					score += 3
-					reasons = append(reasons, fmt.Sprintf("keyword '%s'", kw))
-				}
-			}
+		for _, hit := range keywordHits[t] {
+			score += hit.term.Score(3)
+			reasons = append(reasons, hit.term.Reason(fmt.Sprintf("keyword '%s'", hit.keyword)))
 		}
 
 		// Check capabilities
 		for _, cap := range t.Capabilities {
-			capLower := strings.ToLower(cap)
 			for _, term := range terms {
-				if strings.Contains(capLower, term) {
-					score += 4
-					reasons = append(reasons, fmt.Sprintf("capability matches '%s'", term))
+				if matcher.Contains(cap, term.Text) {
+					score += term.Score(4)
+					reasons = append(reasons, term.Reason(fmt.Sprintf("capability matches '%s'", term.Text)))
 				}
 			}
 		}
 
 		// Check provides
 		for _, p := range t.Provides {
-			pLower := strings.ToLower(p)
 			for _, term := range terms {
-				if strings.Contains(pLower, term) {
-					score += 3
-					reasons = append(reasons, fmt.Sprintf("provides '%s'", p))
+				if matcher.Contains(p, term.Text) {
+					score += term.Score(3)
+					reasons = append(reasons, term.Reason(fmt.Sprintf("provides '%s'", p)))
 				}
 			}
 		}
 
+		// Check category (small bump)
+		for _, term := range terms {
+			if matcher.Contains(t.Category, term.Text) {
+				score += term.Score(2)
+				reasons = append(reasons, term.Reason(fmt.Sprintf("category '%s'", t.Category)))
+			}
+		}
+
+		// Check phrases, words adjacent and in order; in names, words
+		// are joined by dashes
+		for _, phrase := range phrases {
+			if matcher.Contains(t.Name, strings.ReplaceAll(phrase, " ", "-")) {
+				score += 20
+				reasons = append(reasons, fmt.Sprintf("name contains phrase '%s'", phrase))
+			}
+			if matcher.Contains(t.Description, phrase) {
+				score += 10
+				reasons = append(reasons, fmt.Sprintf("description contains phrase '%s'", phrase))
+			}
+			for _, cap := range t.Capabilities {
+				if matcher.Contains(cap, phrase) {
+					score += 8
+					reasons = append(reasons, fmt.Sprintf("capability contains phrase '%s'", phrase))
+				}
+			}
+			for _, kw := range t.Keywords {
+				if matcher.Contains(kw, phrase) {
+					score += 6
+					reasons = append(reasons, fmt.Sprintf("keyword '%s' contains phrase '%s'", kw, phrase))
+				}
+			}
+		}
+
+		// Keywords narrow structural results rather than widen them
+		if len(terms) > 0 && score == 0 {
+			continue
+		}
+		score += structural
+
 		if score > 0 {
 			matches = append(matches, toolMatch{t, score, reasons})
 		}
@@ -149,6 +428,15 @@ func findToolMatches(tools []*tool.Tool, searchTerms string) []toolMatch {
 	return matches
 }
 
+// deprecationNote returns the reason a tool is deprecated, or a generic
+// note if none was given.
+func deprecationNote(t *tool.Tool) string {
+	if t.DeprecatedReason != "" {
+		return t.DeprecatedReason
+	}
+	return "no replacement given"
+}
+
 func printToolMatch(m toolMatch) {
 	t := m.tool
 
@@ -158,6 +446,10 @@ func printToolMatch(m toolMatch) {
 	}
 	fmt.Println()
 
+	if t.Deprecated {
+		fmt.Printf("**Deprecated:** %s\n", deprecationNote(t))
+	}
+
 	fmt.Printf("**File:** `%s`\n", t.File)
 
 	if len(t.Provides) > 0 {
@@ -169,6 +461,9 @@ func printToolMatch(m toolMatch) {
 	if t.Output != "" {
 		fmt.Printf("**Output:** %s\n", t.Output)
 	}
+	if t.Category != "" {
+		fmt.Printf("**Category:** %s\n", t.Category)
+	}
 
 	if len(t.Capabilities) > 0 {
 		fmt.Println()
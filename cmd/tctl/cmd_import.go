@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourname/tctl/internal/config"
+)
+
+func importCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <file>",
+		Short: "Register sources from an exported manifest",
+		Long: `Registers the sources listed in a manifest written by 'tctl export'
+(YAML or JSON), or in a plain text file with one source path per line.
+Blank lines and lines starting with # are ignored.
+
+Sources that are already registered are skipped. Sources whose path
+doesn't exist on this machine are reported and skipped; the rest of the
+import still happens.
+
+Examples:
+  tctl export -o tctl-manifest.yaml   # On the old machine
+  tctl import tctl-manifest.yaml      # On the new machine
+  tctl import sources.txt             # One path per line`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			sources, err := parseImportSources(data)
+			if err != nil {
+				return err
+			}
+			if len(sources) == 0 {
+				fmt.Println("No sources found in", args[0])
+				return nil
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			var added, skipped, missing []string
+			for _, src := range sources {
+				absPath, err := config.ExpandPath(src.Path)
+				if err != nil {
+					missing = append(missing, fmt.Sprintf("%s (%v)", src.Path, err))
+					continue
+				}
+				if info, err := os.Stat(absPath); err != nil || !info.IsDir() {
+					missing = append(missing, src.Path)
+					continue
+				}
+				if isRegistered(cfg, absPath) {
+					skipped = append(skipped, src.Path)
+					continue
+				}
+
+				if err := cfg.AddSource(src.Path, src.Name); err != nil {
+					return err
+				}
+				// AddSource appends; carry over the exported state
+				newSource := &cfg.Sources.Sources[len(cfg.Sources.Sources)-1]
+				newSource.Enabled = src.Enabled
+				newSource.Priority = src.Priority
+				added = append(added, src.Path)
+			}
+
+			if len(added) > 0 {
+				if err := cfg.Save(); err != nil {
+					return err
+				}
+			}
+
+			printImportGroup("✓ Added", added)
+			printImportGroup("- Already registered", skipped)
+			printImportGroup("✗ Not found on this machine", missing)
+			return nil
+		},
+	}
+}
+
+// parseImportSources reads sources from an exported manifest, falling
+// back to a plain list of paths. Sources from a plain list are enabled.
+func parseImportSources(data []byte) ([]manifestSource, error) {
+	// Any YAML or JSON mapping is treated as a manifest
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) == nil && len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+		var m manifest
+		if err := doc.Decode(&m); err != nil {
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+		return m.Sources, nil
+	}
+
+	var sources []manifestSource
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sources = append(sources, manifestSource{Path: line, Enabled: true})
+	}
+	return sources, nil
+}
+
+// isRegistered reports whether a source with the given expanded path exists.
+func isRegistered(cfg *config.Global, absPath string) bool {
+	for _, src := range cfg.Sources.Sources {
+		if src.Dir() == absPath {
+			return true
+		}
+	}
+	return false
+}
+
+func printImportGroup(title string, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	fmt.Printf("%s (%d):\n", title, len(paths))
+	for _, p := range paths {
+		fmt.Printf("  %s\n", p)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/runner"
+	"github.com/yourname/tctl/internal/scanner"
+)
+
+func installCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "install <tool-name>",
+		Short: "Install a tool's @pip packages",
+		Long: `Installs the Python packages a tool declares with @pip, for the same
+interpreter 'tctl run' uses. Uses 'uv pip install' when uv is available,
+otherwise 'python -m pip install'.
+
+Examples:
+  tctl install build-report             # Install its packages
+  tctl install build-report --dry-run   # Print the install command`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			paths := cfg.SourcePaths()
+			if len(paths) == 0 {
+				fmt.Println("No sources registered.")
+				return nil
+			}
+
+			registry, err := scanner.ScanDirectories(paths)
+			if err != nil {
+				return err
+			}
+
+			toolName := args[0]
+			t := registry.Get(toolName)
+			if t == nil {
+				return unknownToolError(toolName, registry)
+			}
+
+			if t.Language != "python" {
+				return fmt.Errorf("%s is a %s tool; only Python tools have @pip packages", t.Name, t.Language)
+			}
+			if len(t.PipRequires) == 0 {
+				fmt.Printf("%s declares no @pip packages.\n", t.Name)
+				return nil
+			}
+
+			python := runner.GetRunnerByLanguage("python").(*runner.PythonRunner)
+			command, err := python.PipInstallCommand(t.PipRequires)
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				fmt.Println(strings.Join(command, " "))
+				return nil
+			}
+
+			fmt.Printf("[tctl] installing for %s: %s\n", t.Name, strings.Join(t.PipRequires, " "))
+			install := exec.Command(command[0], command[1:]...)
+			install.Stdout = os.Stdout
+			install.Stderr = os.Stderr
+			if err := install.Run(); err != nil {
+				return fmt.Errorf("install failed: %w", err)
+			}
+
+			fmt.Printf("✓ Installed packages for %s\n", t.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the install command without running it")
+	return cmd
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+func intentsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "intents",
+		Short: "List defined intents",
+		Long: `Show every intent defined in a source's state.yaml.
+Intents are named workflows that 'tctl get' expands into data and tools.
+
+Examples:
+  tctl intents               # List all intents
+  tctl intents show daily    # Expand 'daily' into the tools it runs`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			if len(cfg.Intents.Intents) == 0 {
+				fmt.Println("No intents defined.")
+				fmt.Println("Define intents in a state.yaml next to a registered source.")
+				return nil
+			}
+
+			registry, err := scanner.ScanDirectories(cfg.SourcePaths())
+			if err != nil {
+				return err
+			}
+
+			// Group intents by the state.yaml that defined them
+			bySource := make(map[string][]string)
+			for name, intent := range cfg.Intents.Intents {
+				bySource[intent.Source] = append(bySource[intent.Source], name)
+			}
+
+			var stateFiles []string
+			for src := range bySource {
+				stateFiles = append(stateFiles, src)
+			}
+			sort.Strings(stateFiles)
+
+			fmt.Println()
+			for _, src := range stateFiles {
+				fmt.Printf("%s:\n", src)
+				fmt.Println()
+
+				names := bySource[src]
+				sort.Strings(names)
+				for _, name := range names {
+					intent := cfg.Intents.Intents[name]
+					fmt.Printf("  %-20s %s\n", name, intent.Description)
+
+					var includes []string
+					for _, item := range intent.Includes {
+						includes = append(includes, describeIntentItem(item, cfg, registry))
+					}
+					if len(includes) > 0 {
+						fmt.Printf("  %-20s includes: %s\n", "", strings.Join(includes, ", "))
+					}
+				}
+				fmt.Println()
+			}
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(intentsShowCmd())
+	return cmd
+}
+
+func intentsShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <intent>",
+		Short: "Expand an intent into the tools and data it triggers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			name := args[0]
+			intent, ok := cfg.GetIntent(name)
+			if !ok {
+				return fmt.Errorf("unknown intent: %s", name)
+			}
+
+			registry, err := scanner.ScanDirectories(cfg.SourcePaths())
+			if err != nil {
+				return err
+			}
+
+			fmt.Println()
+			fmt.Printf("# %s\n", name)
+			fmt.Println()
+			if intent.Description != "" {
+				fmt.Printf("  %s\n", intent.Description)
+				fmt.Println()
+			}
+			fmt.Printf("  Defined in: %s\n", intent.Source)
+			fmt.Println()
+
+			visited := map[string]bool{name: true}
+			for _, item := range intent.Includes {
+				printIntentTree(item, cfg, registry, visited, 1)
+			}
+			fmt.Println()
+
+			return nil
+		},
+	}
+}
+
+// describeIntentItem returns an intent include annotated with what it resolves to.
+func describeIntentItem(item string, cfg *config.Global, registry *tool.Registry) string {
+	if _, ok := cfg.GetIntent(item); ok {
+		return item + " (intent)"
+	}
+	t, err := registry.FindByProvides(item)
+	switch {
+	case err != nil:
+		return item + " (ambiguous)"
+	case t != nil:
+		return fmt.Sprintf("%s (%s)", item, t.Name)
+	}
+	return item + " (unknown)"
+}
+
+// printIntentTree prints an intent item and everything it expands to,
+// following nested intents and each provider's @requires.
+func printIntentTree(item string, cfg *config.Global, registry *tool.Registry, visited map[string]bool, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	if visited[item] {
+		fmt.Printf("%s%s (see above)\n", indent, item)
+		return
+	}
+	visited[item] = true
+
+	if intent, ok := cfg.GetIntent(item); ok {
+		fmt.Printf("%s%s (intent)\n", indent, item)
+		for _, sub := range intent.Includes {
+			printIntentTree(sub, cfg, registry, visited, depth+1)
+		}
+		return
+	}
+
+	t, err := registry.FindByProvides(item)
+	if err != nil {
+		fmt.Printf("%s✗ %v\n", indent, err)
+		return
+	}
+	if t == nil {
+		fmt.Printf("%s✗ %s: no tool provides this\n", indent, item)
+		return
+	}
+
+	fmt.Printf("%s%s → %s\n", indent, item, t.Name)
+	for _, dep := range t.Requires {
+		printIntentTree(dep, cfg, registry, visited, depth+1)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/linter"
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
+)
+
+func lintCmd() *cobra.Command {
+	var llm bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "lint [path]",
+		Short: "Check tools for compatibility issues",
+		Long: `Checks tool files for missing or invalid metadata and exits non-zero if
+any errors are found. Without a path, lints the current directory. A
+directory with a tools/ subdirectory is linted as a project, including
+its state.yaml.
+
+With --diff, lints only the tool files changed in the current git
+repository compared with a ref (default HEAD), as listed by
+'git diff --name-only', plus new files git doesn't track yet. Deleted,
+ignored, and private files, and files no scanner handles, are skipped. Fast enough to run before every commit.
+
+Examples:
+  tctl lint                  # Lint the current directory
+  tctl lint ~/repos/x/tools  # Lint another directory or file
+  tctl lint --llm            # Report formatted for an LLM to act on
+  tctl lint --diff           # Only files changed since HEAD
+  tctl lint --diff main      # Only files changed since main`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var result *linter.Result
+			var label string
+
+			if diff {
+				ref := "HEAD"
+				if len(args) == 1 {
+					ref = args[0]
+				}
+				files, err := gitChangedToolFiles(ref)
+				if err != nil {
+					return err
+				}
+				if len(files) == 0 {
+					fmt.Printf("No tool files changed since %s.\n", ref)
+					return nil
+				}
+
+				result = &linter.Result{}
+				for _, file := range files {
+					result.Merge(linter.LintPath(file))
+				}
+				label = fmt.Sprintf("%d files changed since %s", len(files), ref)
+			} else {
+				path := "."
+				if len(args) == 1 {
+					path = args[0]
+				}
+				result = lintTarget(path)
+				label = path
+			}
+
+			if llm {
+				fmt.Print(linter.FormatResultsForLLM(result, label))
+			} else {
+				printLintResult(result)
+			}
+
+			if !result.OK() {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&llm, "llm", false, "Format the report for an LLM to act on")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Lint only tool files changed since a git ref (the argument, default HEAD)")
+	return cmd
+}
+
+// lintTarget lints path: as a project if it has a tools/ subdirectory,
+// otherwise as a file or directory of tools.
+func lintTarget(path string) *linter.Result {
+	if info, err := os.Stat(filepath.Join(path, "tools")); err == nil && info.IsDir() {
+		return linter.LintProject(path)
+	}
+	return linter.LintPath(path)
+}
+
+// printLintResult prints every finding, most severe first, followed by a
+// summary line.
+func printLintResult(result *linter.Result) {
+	for _, msg := range result.Errors {
+		fmt.Printf("%s %s\n", term.Red("✗"), msg)
+	}
+	for _, msg := range result.Warnings {
+		fmt.Printf("%s %s\n", term.Yellow("⚠"), msg)
+	}
+	for _, msg := range result.Info {
+		fmt.Printf("%s %s\n", term.Dim("ℹ"), msg)
+	}
+
+	if result.OK() && len(result.Warnings) == 0 && len(result.Info) == 0 {
+		fmt.Println(term.Green("✓ No issues found"))
+		return
+	}
+	fmt.Printf("\n%d errors, %d warnings, %d suggestions\n",
+		len(result.Errors), len(result.Warnings), len(result.Info))
+}
+
+// gitChangedToolFiles returns the files in the current git repository that
+// differ from ref, or are new and untracked, and that a scanner handles,
+// skipping deleted and private files, ignored files, and excluded
+// directories.
+func gitChangedToolFiles(ref string) ([]string, error) {
+	top, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("--diff needs a git repository: %w", err)
+	}
+	top = strings.TrimSpace(top)
+
+	out, err := gitOutput("-C", top, "diff", "--name-only", "--diff-filter=d", ref, "--")
+	if err != nil {
+		return nil, fmt.Errorf("git diff %s: %w", ref, err)
+	}
+	untracked, err := gitOutput("-C", top, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files: %w", err)
+	}
+	out += "\n" + untracked
+
+	var files []string
+	for _, name := range strings.Split(strings.TrimSpace(out), "\n") {
+		if name == "" {
+			continue
+		}
+		path := filepath.Join(top, filepath.FromSlash(name))
+		if scanner.IsToolFile(top, path) {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// gitOutput runs git with args and returns its stdout. On failure, the
+// error carries git's own message.
+func gitOutput(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
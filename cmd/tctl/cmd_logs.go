@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/runlog"
+)
+
+func logsCmd() *cobra.Command {
+	var limit int
+	var toolName string
+	var failedOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show recent tool runs",
+		Long: `Show the history of tools run through 'tctl run' and 'tctl get'.
+Each entry shows when the tool ran, its exit code, duration, and arguments.
+
+Examples:
+  tctl logs                      # Last 20 runs
+  tctl logs -n 50                # Last 50 runs
+  tctl logs --tool fetch-prices  # Runs of one tool
+  tctl logs --failed             # Only failed runs`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			records, err := runlog.Read()
+			if err != nil {
+				return err
+			}
+
+			var matched []runlog.Record
+			for _, r := range records {
+				if toolName != "" && r.Tool != toolName {
+					continue
+				}
+				if failedOnly && !r.Failed() {
+					continue
+				}
+				matched = append(matched, r)
+			}
+
+			if len(matched) == 0 {
+				fmt.Println("No runs logged.")
+				return nil
+			}
+
+			if limit > 0 && len(matched) > limit {
+				matched = matched[len(matched)-limit:]
+			}
+
+			fmt.Println()
+			for _, r := range matched {
+				icon := "✓"
+				status := fmt.Sprintf("exit %d", r.ExitCode)
+				if r.Failed() {
+					icon = "✗"
+				}
+				if r.Error != "" {
+					status = r.Error
+				}
+
+				fmt.Printf("  %s %s %-24s %-8s %7.2fs  %s\n",
+					icon,
+					r.Time.Local().Format("2006-01-02 15:04:05"),
+					r.Tool,
+					status,
+					r.Duration().Seconds(),
+					strings.Join(r.Args, " "))
+			}
+			fmt.Println()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Number of runs to show (0 for all)")
+	cmd.Flags().StringVarP(&toolName, "tool", "t", "", "Only show runs of this tool")
+	cmd.Flags().BoolVar(&failedOnly, "failed", false, "Only show failed runs")
+	return cmd
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
+)
+
+func moveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "move <tool> <source>",
+		Short: "Move a tool's file to another source",
+		Long: `Moves a tool's file from its current source into the directory of another
+registered source, given by name, keeping the file name and contents. A
+sidecar metadata file next to the tool moves with it.
+
+Refuses to overwrite: if the target directory already has a file of that
+name, nothing is moved. Relative @output paths resolve against the new
+location afterwards, so check them with 'tctl show' if the sources lay
+out their data differently.
+
+Examples:
+  tctl move fetch-prices finance   # Into the source named finance`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			toolName, sourceName := args[0], args[1]
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			src := cfg.FindSourceByName(sourceName)
+			if src == nil {
+				return fmt.Errorf("no source named '%s' (see 'tctl sources')", sourceName)
+			}
+
+			registry, err := scanner.ScanDirectories(cfg.SourcePaths())
+			if err != nil {
+				return err
+			}
+			t := registry.Get(toolName)
+			if t == nil {
+				return unknownToolError(toolName, registry)
+			}
+
+			if t.Entrypoint != "" {
+				return fmt.Errorf("%s is a console script in %s; move its project instead", toolName, t.File)
+			}
+
+			dir := src.Dir()
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				return fmt.Errorf("source '%s' directory %s does not exist", sourceName, dir)
+			}
+			if filepath.Dir(t.File) == dir {
+				fmt.Printf("%s is already in %s\n", toolName, dir)
+				return nil
+			}
+
+			// Check every destination before moving anything
+			moves := [][2]string{{t.File, filepath.Join(dir, filepath.Base(t.File))}}
+			if scanner.HasSidecar(t.File) {
+				sidecar := scanner.SidecarPath(t.File)
+				moves = append(moves, [2]string{sidecar, filepath.Join(dir, filepath.Base(sidecar))})
+			}
+			for _, m := range moves {
+				if _, err := os.Lstat(m[1]); err == nil {
+					return fmt.Errorf("%s already exists; not moving %s", m[1], toolName)
+				}
+			}
+
+			// A tool is never left split across two sources: if a move
+			// fails, the ones before it are undone
+			for i, m := range moves {
+				if err := moveFile(m[0], m[1]); err != nil {
+					for j := i - 1; j >= 0; j-- {
+						if undoErr := moveFile(moves[j][1], moves[j][0]); undoErr != nil {
+							return fmt.Errorf("%v; could not move %s back: %v", err, moves[j][1], undoErr)
+						}
+					}
+					return err
+				}
+			}
+
+			fmt.Printf("✓ Moved %s to %s\n", toolName, moves[0][1])
+			fmt.Println()
+			fmt.Println("Run 'tctl sync' to rebuild the tool cache.")
+			return nil
+		},
+	}
+}
+
+// moveFile renames src to dst, copying and then removing src when they are
+// on different file systems. If it fails, src is left in place and no
+// copy is left at dst.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	if err := os.Remove(src); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return nil
+}
@@ -1,25 +1,65 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/freshness"
 	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
 	"github.com/yourname/tctl/pkg/tool"
 )
 
 func showCmd() *cobra.Command {
-	return &cobra.Command{
+	var interfaceOnly bool
+	var deps bool
+	var markdown bool
+	var jsonSchemaOnly bool
+	var showFreshness bool
+	var outputDir string
+
+	cmd := &cobra.Command{
 		Use:   "show <tool-name>",
 		Short: "Show detailed information about a tool",
 		Long: `Displays all metadata extracted from a tool's docstring:
   - Capabilities and boundaries
   - Input/output specifications
   - Interface arguments
-  - Usage examples`,
+  - Usage examples
+
+With --interface, prints only the tool's arguments as JSON, for
+wrappers and other tooling that build or validate calls.
+
+With --json-schema, prints a draft-07 JSON Schema for the arguments
+instead, e.g. to generate an input form. Declared types map to JSON
+types, choices to enum, and required arguments to the required list.
+
+With --markdown, prints the tool as a standalone Markdown page instead,
+for publishing in a docs site.
+
+With --deps, also prints the tools whose data this one @requires and the
+tools that @require what it provides - everything an edit could affect.
+
+With --freshness, also prints the absolute path 'tctl status' checks for
+the tool's @output, what relative paths were resolved against, and the
+freshness result. Use it when status reports data missing that you can
+see on disk. --output-dir (default $TCTL_OUTPUT_DIR) resolves against
+another directory, as it does for status.
+
+Examples:
+  tctl show fetch-prices
+  tctl show fetch-prices --interface
+  tctl show fetch-prices --json-schema
+  tctl show fetch-prices --deps
+  tctl show fetch-prices --freshness
+  tctl show fetch-prices --markdown > docs/fetch-prices.md`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
@@ -41,18 +81,61 @@ func showCmd() *cobra.Command {
 			toolName := args[0]
 			t := registry.Get(toolName)
 			if t == nil {
-				fmt.Printf("Unknown tool: %s\n", toolName)
-				fmt.Println("Run 'tctl list' to see available tools.")
+				return unknownToolError(toolName, registry)
+			}
+
+			if interfaceOnly {
+				return printInterfaceJSON(t)
+			}
+			if jsonSchemaOnly {
+				return printJSON(interfaceSchema(t))
+			}
+			if markdown {
+				writeToolMarkdown(os.Stdout, t, registry, 1)
 				return nil
 			}
 
-			printToolDetails(t)
+			printToolDetails(t, registry)
+			if deps {
+				printToolDeps(t, registry)
+			}
+			if showFreshness {
+				outputDir, err := resolveOutputDir(outputDir)
+				if err != nil {
+					return err
+				}
+				printToolFreshness(t, outputDir)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&interfaceOnly, "interface", false, "Print the argument spec as JSON")
+	cmd.Flags().BoolVar(&deps, "deps", false, "Also show upstream and downstream tools")
+	cmd.Flags().BoolVar(&markdown, "markdown", false, "Print the tool as a Markdown page")
+	cmd.Flags().BoolVar(&jsonSchemaOnly, "json-schema", false, "Print a JSON Schema for the arguments")
+	cmd.Flags().BoolVar(&showFreshness, "freshness", false, "Also show the resolved output path and its freshness")
+	cmd.Flags().StringVar(&outputDir, "output-dir", os.Getenv(outputDirEnv), "Resolve relative @output paths against this directory")
+	cmd.MarkFlagsMutuallyExclusive("interface", "markdown", "json-schema")
+	return cmd
 }
 
-func printToolDetails(t *tool.Tool) {
+// printInterfaceJSON prints the tool's interface arguments as a JSON array.
+func printInterfaceJSON(t *tool.Tool) error {
+	return printJSON(t.InterfaceArgs())
+}
+
+// printJSON prints v as indented JSON.
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false) // keep positional names like <path> readable
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printToolDetails prints all of a tool's metadata. registry is used to
+// describe related tools.
+func printToolDetails(t *tool.Tool, registry *tool.Registry) {
 	fmt.Println()
 	fmt.Printf("# %s\n", t.Name)
 	fmt.Println()
@@ -62,7 +145,15 @@ func printToolDetails(t *tool.Tool) {
 		fmt.Println()
 	}
 
+	if t.Deprecated {
+		fmt.Printf("  ⚠ Deprecated: %s\n", deprecationNote(t))
+		fmt.Println()
+	}
+
 	fmt.Printf("  File: %s\n", t.File)
+	if t.Entrypoint != "" {
+		fmt.Printf("  Entry point: %s\n", t.Entrypoint)
+	}
 	fmt.Printf("  Language: %s\n", t.Language)
 
 	if t.Version != "" {
@@ -71,10 +162,45 @@ func printToolDetails(t *tool.Tool) {
 
 	fmt.Printf("  Provides: %s\n", strings.Join(t.Provides, ", "))
 	if len(t.Requires) > 0 {
-		fmt.Printf("  Requires: %s\n", strings.Join(t.Requires, ", "))
+		var reqs []string
+		for _, r := range t.Requires {
+			if c := t.Constraint(r); c != nil {
+				r = c.String()
+			}
+			reqs = append(reqs, r)
+		}
+		fmt.Printf("  Requires: %s\n", strings.Join(reqs, ", "))
+	}
+	if len(t.Runtime) > 0 {
+		var runtime []string
+		for _, r := range t.Runtime {
+			runtime = append(runtime, r.String())
+		}
+		fmt.Printf("  Runtime: %s\n", strings.Join(runtime, ", "))
+	}
+	if len(t.PipRequires) > 0 {
+		fmt.Printf("  Pip: %s\n", strings.Join(t.PipRequires, ", "))
+	}
+	if len(t.RequiresCmd) > 0 {
+		fmt.Printf("  Commands: %s\n", strings.Join(t.RequiresCmd, ", "))
+	}
+	if len(t.RequiresEnv) > 0 {
+		fmt.Printf("  Environment: %s\n", strings.Join(t.RequiresEnv, ", "))
 	}
 	fmt.Printf("  Output: %s\n", t.Output)
+	if t.OutputFormat != "" {
+		fmt.Printf("  Output format: %s\n", t.OutputFormat)
+	}
 	fmt.Printf("  Freshness: %s\n", t.Freshness)
+	if t.ConcurrencyUnsafe {
+		fmt.Println("  Concurrency: runs one at a time with other unsafe tools")
+	}
+	if t.Category != "" {
+		fmt.Printf("  Category: %s\n", t.Category)
+	}
+	if len(t.Tags) > 0 {
+		fmt.Printf("  Tags: %s\n", strings.Join(t.Tags, ", "))
+	}
 
 	if len(t.Capabilities) > 0 {
 		fmt.Println()
@@ -100,15 +226,21 @@ func printToolDetails(t *tool.Tool) {
 	if len(t.Interface) > 0 {
 		fmt.Println()
 		fmt.Println("  Interface:")
-		for name, arg := range t.Interface {
+		for _, arg := range t.InterfaceArgs() {
 			req := ""
 			if arg.Required {
 				req = " (required)"
 			}
-			fmt.Printf("    %s: %s%s\n", name, arg.Type, req)
+			fmt.Printf("    %s: %s%s\n", arg.Name, arg.Type, req)
 			if arg.Description != "" {
 				fmt.Printf("      %s\n", arg.Description)
 			}
+			if len(arg.Choices) > 0 {
+				fmt.Printf("      choices: %s\n", strings.Join(arg.Choices, " | "))
+			}
+			if arg.Default != "" {
+				fmt.Printf("      default: %s\n", arg.Default)
+			}
 		}
 	}
 
@@ -116,9 +248,139 @@ func printToolDetails(t *tool.Tool) {
 		fmt.Println()
 		fmt.Println("  Examples:")
 		for _, ex := range t.Examples {
-			fmt.Printf("    $ %s\n", ex)
+			if ex.Label != "" {
+				fmt.Printf("    %s:\n", ex.Label)
+			}
+			fmt.Printf("    $ %s\n", ex.Command)
+		}
+	}
+
+	if len(t.Related) > 0 {
+		fmt.Println()
+		fmt.Println("  Related tools:")
+		for _, name := range t.Related {
+			switch other := registry.Get(name); {
+			case other == nil:
+				fmt.Printf("    → %s (not found)\n", name)
+			case other.Description != "":
+				fmt.Printf("    → %s - %s\n", name, other.Description)
+			default:
+				fmt.Printf("    → %s\n", name)
+			}
 		}
 	}
 
 	fmt.Println()
 }
+
+// printToolDeps prints the providers of t's @requires and the tools that
+// require any of t's @provides.
+func printToolDeps(t *tool.Tool, registry *tool.Registry) {
+	fmt.Println("  Depends on:")
+	if len(t.Requires) == 0 {
+		fmt.Println("    (nothing)")
+	}
+	for _, req := range t.Requires {
+		provider, err := registry.FindByProvides(req)
+		switch {
+		case err != nil:
+			fmt.Printf("    ← ? (%v)\n", err)
+		case provider != nil:
+			fmt.Printf("    ← %s (provides %s)\n", provider.Name, req)
+		default:
+			fmt.Printf("    ← ? (no tool provides %s)\n", req)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("  Depended on by:")
+	seen := make(map[string]bool)
+	for _, data := range t.Provides {
+		for _, dependent := range registry.Dependents(data) {
+			if seen[dependent.Name] {
+				continue
+			}
+			seen[dependent.Name] = true
+			fmt.Printf("    → %s (requires %s)\n", dependent.Name, data)
+		}
+	}
+	if len(seen) == 0 {
+		fmt.Println("    (nothing)")
+	}
+
+	fmt.Println()
+}
+
+// printToolFreshness prints the path 'tctl status' checks for t's @output,
+// resolving a relative one against outputDir if it is set, and whether
+// that path is fresh.
+func printToolFreshness(t *tool.Tool, outputDir string) {
+	fmt.Println("  Freshness check:")
+	if t.Output == "" {
+		fmt.Println("    (no @output, so nothing to check)")
+		fmt.Println()
+		return
+	}
+
+	path := t.OutputPathIn(outputDir)
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	fmt.Printf("    Declared: %s\n", t.Output)
+	switch {
+	case filepath.IsAbs(t.Output):
+		fmt.Println("    Resolved against: (absolute path)")
+	case outputDir != "":
+		fmt.Printf("    Resolved against: %s (--output-dir)\n", outputDir)
+	case t.OutputBase != "":
+		fmt.Printf("    Resolved against: %s (source output_base)\n", t.OutputBase)
+	default:
+		fmt.Printf("    Resolved against: %s (parent of the tool's directory)\n", filepath.Dir(filepath.Dir(t.File)))
+	}
+	fmt.Printf("    Path: %s\n", path)
+
+	checked := path
+	if freshness.IsGlob(path) {
+		newest, err := freshness.Newest(path)
+		if err != nil {
+			fmt.Println("    Newest match: (none)")
+			checked = ""
+		} else {
+			fmt.Printf("    Newest match: %s\n", newest)
+			checked = newest
+		}
+	}
+	if checked != "" {
+		info, err := os.Stat(checked)
+		switch {
+		case err != nil:
+			fmt.Printf("    Exists: no (%v)\n", errors.Unwrap(err))
+		case info.IsDir():
+			fmt.Println("    Exists: yes, a directory (as fresh as its newest file)")
+		default:
+			fmt.Println("    Exists: yes")
+		}
+	}
+
+	policy := t.Freshness
+	if _, ok := freshness.Thresholds[policy]; !ok {
+		policy = "manual"
+	}
+	fmt.Printf("    Policy: %s\n", t.Freshness)
+	if policy != "manual" {
+		fmt.Printf("    Stale after: %s\n", freshness.Thresholds[policy])
+	}
+
+	fresh, msg := freshness.Check(path, t.Freshness)
+	icon := term.Green("✓")
+	if !fresh {
+		if strings.Contains(msg, "missing") {
+			icon = term.Red("✗")
+		} else {
+			icon = term.Yellow("⚠")
+		}
+	}
+	fmt.Printf("    Result: %s %s\n", icon, msg)
+	fmt.Println()
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -19,8 +20,12 @@ func sourcesCmd() *cobra.Command {
 		Long: `Show all directories registered with tctl.
 
 Examples:
-  tctl sources           # List all sources
-  tctl sources --tools   # Include tool counts`,
+  tctl sources                 # List all sources
+  tctl sources --tools         # Include tool counts
+  tctl sources disable work    # Exclude 'work' from scans
+  tctl sources enable work     # Include it again
+  tctl sources prioritize personal 10  # Let 'personal' win name collisions
+  tctl sources check --prune   # Remove sources whose directory is gone`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -42,7 +47,7 @@ Examples:
 			for _, src := range cfg.Sources.Sources {
 				// Check if path exists
 				exists := "✓"
-				if _, err := os.Stat(src.Path); os.IsNotExist(err) {
+				if _, err := os.Stat(src.Dir()); os.IsNotExist(err) {
 					exists = "✗"
 				}
 
@@ -51,10 +56,20 @@ Examples:
 					name = "(unnamed)"
 				}
 
-				fmt.Printf("  %s %-16s %s\n", exists, name, src.Path)
+				priority := ""
+				if src.Priority != 0 {
+					priority = fmt.Sprintf(" (priority %d)", src.Priority)
+				}
+
+				if !src.Enabled {
+					fmt.Printf("  %s %-16s %s%s [disabled]\n", exists, name, src.Path, priority)
+					continue
+				}
+
+				fmt.Printf("  %s %-16s %s%s\n", exists, name, src.Path, priority)
 
 				if showTools {
-					registry, err := scanner.ScanDirectory(src.Path)
+					registry, err := scanner.ScanDirectory(src.Dir())
 					if err == nil {
 						tools := registry.All()
 						for _, t := range tools {
@@ -75,6 +90,127 @@ Examples:
 	}
 
 	cmd.Flags().BoolVarP(&showTools, "tools", "t", false, "Show tools in each source")
+
+	cmd.AddCommand(sourcesToggleCmd("enable", true))
+	cmd.AddCommand(sourcesToggleCmd("disable", false))
+	cmd.AddCommand(sourcesPrioritizeCmd())
+	cmd.AddCommand(sourcesCheckCmd())
+	return cmd
+}
+
+func sourcesCheckCmd() *cobra.Command {
+	var prune bool
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Report sources whose directory no longer exists",
+		Long: `Check that every registered source is still a reachable directory.
+With --prune, unreachable sources are unregistered.
+
+Examples:
+  tctl sources check           # List unreachable sources
+  tctl sources check --prune   # ...and remove them`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			var dead []config.Source
+			for _, src := range cfg.Sources.Sources {
+				info, err := os.Stat(src.Dir())
+				if err != nil || !info.IsDir() {
+					dead = append(dead, src)
+				}
+			}
+
+			if len(dead) == 0 {
+				fmt.Printf("✓ All %d sources are reachable\n", len(cfg.Sources.Sources))
+				return nil
+			}
+
+			for _, src := range dead {
+				if !prune {
+					fmt.Printf("  ✗ %-16s %s\n", src.Name, src.Path)
+					continue
+				}
+				if err := cfg.RemoveSource(src.Path); err != nil {
+					return err
+				}
+				fmt.Printf("  ✓ Removed %-16s %s\n", src.Name, src.Path)
+			}
+
+			if !prune {
+				fmt.Println()
+				fmt.Printf("%d unreachable sources. Run 'tctl sources check --prune' to remove them.\n", len(dead))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&prune, "prune", false, "Unregister unreachable sources")
 	return cmd
 }
 
+func sourcesPrioritizeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "prioritize <path-or-name> <priority>",
+		Short: "Set which source wins when tool names collide",
+		Long: `Set the priority of a source. When two sources define a tool with the
+same name, the tool from the source with the higher priority is used.
+Sources with equal priority fall back to registration order (last wins).
+The default priority is 0; negative values are allowed.
+
+Examples:
+  tctl sources prioritize personal 10
+  tctl sources prioritize shared -1`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			priority, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid priority: %s", args[1])
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.SetSourcePriority(args[0], priority); err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Priority of %s set to %d\n", args[0], priority)
+			return nil
+		},
+	}
+}
+
+// sourcesToggleCmd builds the 'sources enable' and 'sources disable' subcommands.
+func sourcesToggleCmd(use string, enabled bool) *cobra.Command {
+	short, done := "Include a source in scans", "Enabled"
+	if !enabled {
+		short, done = "Exclude a source from scans without removing it", "Disabled"
+	}
+
+	return &cobra.Command{
+		Use:   use + " <path-or-name>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.SetSourceEnabled(args[0], enabled); err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ %s: %s\n", done, args[0])
+			return nil
+		},
+	}
+}
+
@@ -1,23 +1,43 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"path/filepath"
+	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/yourname/tctl/internal/config"
 	"github.com/yourname/tctl/internal/freshness"
 	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
 )
 
 func statusCmd() *cobra.Command {
-	return &cobra.Command{
+	var watch bool
+	var interval time.Duration
+	var outputDir string
+
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show data freshness status",
 		Long: `Displays the freshness status of all data outputs.
-Shows which data is fresh, stale, or missing.`,
+Shows which data is fresh, stale, or missing.
+
+With --watch, the table is redrawn every --interval until Ctrl-C.
+
+--output-dir (default $TCTL_OUTPUT_DIR) resolves relative @output paths
+against another directory, as 'tctl get --output-dir' does.
+
+Examples:
+  tctl status                       # Show status once
+  tctl status --watch               # Refresh every 5s
+  tctl status --watch --interval 1m # Refresh every minute
+  tctl status --output-dir /tmp/ci  # Status of outputs under /tmp/ci`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -30,56 +50,108 @@ Shows which data is fresh, stale, or missing.`,
 				return nil
 			}
 
-			registry, err := scanner.ScanDirectories(paths)
-			if err != nil {
+			if outputDir, err = resolveOutputDir(outputDir); err != nil {
 				return err
 			}
 
-			tools := registry.All()
+			if !watch {
+				return printStatus(paths, outputDir)
+			}
 
-			fmt.Println()
-			fmt.Println("📊 Data Status")
-			fmt.Println()
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			return watchStatus(paths, outputDir, interval)
+		},
+	}
 
-			hasData := false
-			for _, t := range tools {
-				if t.Output == "" {
-					continue
-				}
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep refreshing the status")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Refresh interval for --watch")
+	cmd.Flags().StringVar(&outputDir, "output-dir", os.Getenv(outputDirEnv), "Resolve relative @output paths against this directory")
+	return cmd
+}
 
-				hasData = true
+// watchStatus redraws the status table every interval until interrupted.
+func watchStatus(paths []string, outputDir string, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-				// Output path is relative to the tool's directory
-				outputPath := t.Output
-				if !filepath.IsAbs(outputPath) {
-					outputPath = filepath.Join(filepath.Dir(t.File), "..", t.Output)
-				}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-				fresh, msg := freshness.Check(outputPath, t.Freshness)
+	for {
+		// Clear the screen and move the cursor home
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("Every %s: tctl status    %s\n", interval, time.Now().Format("15:04:05"))
+		if err := printStatus(paths, outputDir); err != nil {
+			return err
+		}
 
-				icon := "✓"
-				if !fresh {
-					if strings.Contains(msg, "missing") {
-						icon = "✗"
-					} else {
-						icon = "⚠"
-					}
-				}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
 
-				dataName := t.Name
-				if len(t.Provides) > 0 {
-					dataName = t.Provides[0]
-				}
+// printStatus scans paths and prints the freshness of every tool output,
+// resolving relative outputs against outputDir if it is set.
+func printStatus(paths []string, outputDir string) error {
+	registry, err := scanner.ScanDirectories(paths)
+	if err != nil {
+		return err
+	}
 
-				fmt.Printf("  %s %-24s %s\n", icon, dataName, msg)
+	// Sorted so rows stay put between --watch redraws
+	tools := registry.All()
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Name < tools[j].Name
+	})
+
+	fmt.Println()
+	fmt.Println("📊 Data Status")
+	fmt.Println()
+
+	hasData := false
+	for _, t := range tools {
+		if t.Output == "" {
+			continue
+		}
+
+		hasData = true
+
+		path := t.OutputPathIn(outputDir)
+		fresh, msg := freshness.Check(path, t.Freshness)
+
+		icon := term.Green("✓")
+		if !fresh {
+			if strings.Contains(msg, "missing") {
+				icon = term.Red("✗")
+			} else {
+				icon = term.Yellow("⚠")
 			}
+		}
+
+		dataName := t.Name
+		if len(t.Provides) > 0 {
+			dataName = t.Provides[0]
+		}
 
-			if !hasData {
-				fmt.Println("  No tools with @output defined.")
+		// For a glob @output, show which file the freshness is from
+		if freshness.IsGlob(path) {
+			if newest, err := freshness.Newest(path); err == nil {
+				msg += "  " + term.Dim(newest)
 			}
+		}
 
-			fmt.Println()
-			return nil
-		},
+		fmt.Printf("  %s %-24s %s\n", icon, dataName, msg)
 	}
+
+	if !hasData {
+		fmt.Println("  No tools with @output defined.")
+	}
+
+	fmt.Println()
+	return nil
 }
@@ -1,20 +1,55 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/yourname/tctl/internal/cache"
 	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/linter"
 	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
+	"github.com/yourname/tctl/pkg/tool"
 )
 
+// syncDebounce is how long a file must stay unchanged before
+// 'tctl sync --watch' rescans it, so a burst of saves is handled once.
+const syncDebounce = 300 * time.Millisecond
+
 func syncCmd() *cobra.Command {
-	return &cobra.Command{
+	var watch bool
+	var interval time.Duration
+	var strict, warningsAsErrors bool
+
+	cmd := &cobra.Command{
 		Use:   "sync",
 		Short: "Rescan all sources and validate tools",
 		Long: `Scans all registered source directories and validates tools.
-Run this after adding or modifying tool files.`,
+Run this after adding or modifying tool files. The tools found are
+cached; 'tctl diff' compares against this cache.
+
+Tools that write the same @output are reported, resolving relative paths
+against $TCTL_OUTPUT_DIR if it is set, as 'tctl get' does.
+
+With --watch, keeps polling the sources and rescans and lints each file
+as it changes, until Ctrl-C.
+
+With --validate-strict, also lints every source as 'tctl lint' would and
+exits non-zero if there are any errors, making sync usable as a CI gate.
+--warnings-as-errors fails on warnings too (and implies --validate-strict).
+
+Examples:
+  tctl sync                    # Scan and validate once
+  tctl sync --watch            # Revalidate tools as you save them
+  tctl sync --validate-strict  # Fail if any tool has lint errors
+  tctl sync --warnings-as-errors`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -37,29 +72,225 @@ Run this after adding or modifying tool files.`,
 
 			tools := registry.All()
 			fmt.Printf("[sync] Found %d tools\n", len(tools))
+			if len(registry.Errors) > 0 {
+				fmt.Printf("[sync] %s %d files failed to scan:\n", term.Red("✗"), len(registry.Errors))
+				for _, scanErr := range registry.Errors {
+					fmt.Printf("  %s %s\n", term.Red("✗"), scanErr.Error())
+				}
+			}
+
+			if err := cache.Save(registry); err != nil {
+				fmt.Printf("[sync] %s could not write the tool cache: %v\n", term.Yellow("⚠"), err)
+			}
 
 			// Validate
 			fmt.Println("[sync] Validating...")
 			hasErrors := false
 			for _, t := range tools {
 				if t.Name == "" {
-					fmt.Printf("  ⚠ %s: missing @tool tag\n", t.File)
+					fmt.Printf("  %s %s: missing @tool tag\n", term.Yellow("⚠"), t.File)
 					hasErrors = true
 				}
 				if len(t.Provides) == 0 {
-					fmt.Printf("  ⚠ %s: missing @provides tag\n", t.Name)
+					fmt.Printf("  %s %s: missing @provides tag\n", term.Yellow("⚠"), t.Name)
+				}
+			}
+			// Outputs land in $TCTL_OUTPUT_DIR when 'tctl get' uses it
+			outputDir, err := resolveOutputDir(os.Getenv(outputDirEnv))
+			if err != nil {
+				return err
+			}
+			collisions := tool.OutputCollisions(tools, outputDir)
+			var collided []string
+			for path := range collisions {
+				collided = append(collided, path)
+			}
+			sort.Strings(collided)
+			for _, path := range collided {
+				var names []string
+				for _, t := range collisions[path] {
+					names = append(names, t.Name)
 				}
+				fmt.Printf("  %s %s: written by %s\n", term.Yellow("⚠"), path, strings.Join(names, " and "))
+				hasErrors = true
 			}
 
-			if hasErrors {
+			if hasErrors || len(registry.Errors) > 0 {
 				fmt.Println()
-				fmt.Println("[sync] ⚠ Some tools have issues. Run 'tctl doctor' for details.")
+				fmt.Println("[sync]", term.Yellow("⚠ Some tools have issues."), "Run 'tctl doctor' for details.")
 			} else {
-				fmt.Println("[sync] ✓ All tools valid")
+				fmt.Println("[sync]", term.Green("✓ All tools valid"))
 			}
 
 			fmt.Println()
+
+			if strict || warningsAsErrors {
+				if !lintSources(paths, warningsAsErrors) {
+					os.Exit(1)
+				}
+				return nil
+			}
+
+			if watch {
+				if interval <= 0 {
+					return fmt.Errorf("--interval must be positive")
+				}
+				return watchSources(paths, registry, interval)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep watching sources for changes")
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "Polling interval for --watch")
+	cmd.Flags().BoolVar(&strict, "validate-strict", false, "Lint all sources and exit non-zero on errors")
+	cmd.Flags().BoolVar(&warningsAsErrors, "warnings-as-errors", false, "With --validate-strict, also fail on warnings")
+	cmd.MarkFlagsMutuallyExclusive("watch", "validate-strict")
+	cmd.MarkFlagsMutuallyExclusive("watch", "warnings-as-errors")
+	return cmd
+}
+
+// lintSources lints every source for 'tctl sync --validate-strict',
+// prints its errors and warnings and a count per severity, and reports
+// whether the sources pass: no errors, and no warnings if
+// warningsAsErrors is set.
+func lintSources(paths []string, warningsAsErrors bool) bool {
+	fmt.Println("[sync] Linting...")
+	result := &linter.Result{}
+	for _, path := range paths {
+		result.Merge(lintTarget(path))
+	}
+
+	for _, msg := range result.Errors {
+		fmt.Printf("  %s %s\n", term.Red("✗"), msg)
+	}
+	for _, msg := range result.Warnings {
+		fmt.Printf("  %s %s\n", term.Yellow("⚠"), msg)
+	}
+
+	fmt.Printf("[sync] %d errors, %d warnings, %d suggestions\n",
+		len(result.Errors), len(result.Warnings), len(result.Info))
+
+	pass := result.OK() && (!warningsAsErrors || len(result.Warnings) == 0)
+	switch {
+	case !pass && warningsAsErrors:
+		fmt.Println("[sync]", term.Red("✗ Lint failed (warnings count as errors)"))
+	case !pass:
+		fmt.Println("[sync]", term.Red("✗ Lint failed"))
+	default:
+		fmt.Println("[sync]", term.Green("✓ Lint passed"))
+	}
+	fmt.Println()
+	return pass
+}
+
+// watchSources polls paths for changed tool files and rescans and lints
+// each one. registry is the result of the initial scan.
+func watchSources(paths []string, registry *tool.Registry, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Tool name of each known tool file
+	known := make(map[string]string)
+	for _, t := range registry.All() {
+		known[t.File] = t.Name
+	}
+
+	modTimes := snapshotToolFiles(paths)
+	pending := make(map[string]time.Time) // changed file -> when it was last seen changing
+
+	fmt.Printf("[sync] Watching %d sources (Ctrl-C to stop)...\n", len(paths))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		current := snapshotToolFiles(paths)
+		for path, mod := range current {
+			if prev, ok := modTimes[path]; !ok || !prev.Equal(mod) {
+				pending[path] = now
+			}
+		}
+		for path := range modTimes {
+			if _, ok := current[path]; !ok {
+				pending[path] = now
+			}
+		}
+		modTimes = current
+
+		for path, changed := range pending {
+			if now.Sub(changed) < syncDebounce {
+				continue
+			}
+			delete(pending, path)
+			if _, exists := current[path]; !exists {
+				if name, ok := known[path]; ok {
+					fmt.Printf("[sync] %s %s removed (%s)\n", term.Red("-"), name, path)
+					delete(known, path)
+				}
+				continue
+			}
+			rescanToolFile(path, known)
+		}
+	}
+}
+
+// snapshotToolFiles returns the modification time of every tool file
+// under paths.
+func snapshotToolFiles(paths []string) map[string]time.Time {
+	files := make(map[string]time.Time)
+	for _, dir := range paths {
+		scanner.WalkToolFiles(dir, func(path string, info os.FileInfo) {
+			files[path] = info.ModTime()
+			// An edited sidecar counts as a change to its tool
+			if side, err := os.Stat(scanner.SidecarPath(path)); err == nil && side.ModTime().After(info.ModTime()) {
+				files[path] = side.ModTime()
+			}
+		})
+	}
+	return files
+}
+
+// rescanToolFile rescans and lints a changed file and reports the result.
+func rescanToolFile(path string, known map[string]string) {
+	t, _ := scanner.ScanFile(path)
+
+	prevName, wasTool := known[path]
+	switch {
+	case t != nil && !wasTool:
+		fmt.Printf("[sync] %s new tool %s (%s)\n", term.Green("+"), t.Name, path)
+		known[path] = t.Name
+	case t != nil:
+		if t.Name != prevName {
+			fmt.Printf("[sync] %s %s renamed to %s (%s)\n", term.Yellow("~"), prevName, t.Name, path)
+		} else {
+			fmt.Printf("[sync] %s %s updated (%s)\n", term.Yellow("~"), t.Name, path)
+		}
+		known[path] = t.Name
+	case wasTool:
+		fmt.Printf("[sync] %s %s no longer parses as a tool (%s)\n", term.Red("✗"), prevName, path)
+		delete(known, path)
+	default:
+		// An ordinary source file that isn't a tool
+		return
+	}
+
+	result := linter.LintPath(path)
+	for _, msg := range result.Errors {
+		fmt.Printf("  %s %s\n", term.Red("✗"), msg)
+	}
+	for _, msg := range result.Warnings {
+		fmt.Printf("  %s %s\n", term.Yellow("⚠"), msg)
+	}
+	if result.OK() && len(result.Warnings) == 0 {
+		fmt.Printf("  %s\n", term.Green("✓ valid"))
+	}
 }
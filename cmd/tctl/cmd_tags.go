@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
+)
+
+func tagsCmd() *cobra.Command {
+	var language string
+
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "List the metadata tags tctl understands",
+		Long: `Show every @tag the scanners understand and what it is for. Tags that
+only some languages honor are marked with them. With --language, shows
+only that language's tags.
+
+Examples:
+  tctl tags                       # Every tag
+  tctl tags --language typescript # Tags that work in TypeScript tools`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scanners := scanner.AllScanners()
+			if language != "" {
+				s := scanner.GetScannerByLanguage(strings.ToLower(language))
+				if s == nil {
+					warnUnknownLanguages([]string{language})
+					return nil
+				}
+				scanners = []scanner.Scanner{s}
+			}
+
+			// Tags in the order the scanners declare them, each with the
+			// languages that honor it
+			var tags []scanner.TagInfo
+			languages := make(map[string][]string)
+			for _, s := range scanners {
+				for _, tag := range s.Tags() {
+					if languages[tag.Name] == nil {
+						tags = append(tags, tag)
+					}
+					languages[tag.Name] = append(languages[tag.Name], s.Language())
+				}
+			}
+
+			fmt.Println()
+			fmt.Println("Tags:")
+			for _, tag := range tags {
+				usage := strings.TrimSpace(tag.Name + " " + tag.Syntax)
+				desc := tag.Description
+				if langs := languages[tag.Name]; len(langs) < len(scanners) {
+					desc += " " + term.Dim("("+strings.Join(langs, ", ")+" only)")
+				}
+				fmt.Printf("  %-36s %s\n", usage, desc)
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&language, "language", "", "Only tags this language honors")
+	return cmd
+}
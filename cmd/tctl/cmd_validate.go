@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/linter"
+	"github.com/yourname/tctl/internal/term"
+)
+
+func validateCmd() *cobra.Command {
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "validate <file>",
+		Short: "Check that a single file is a valid tool",
+		Long: `Lint exactly one file and exit non-zero if it isn't a valid tool.
+Only blocking findings are printed, followed by a one-line PASS/FAIL.
+With --strict, warnings also fail the check.
+
+Designed for git pre-commit hooks, e.g.:
+  git diff --cached --name-only -- '*.py' '*.ts' | xargs -n1 tctl validate --strict`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return fmt.Errorf("%s is a directory; use 'tctl lint' for directories", path)
+			}
+
+			result := linter.LintPath(path)
+
+			blocking := result.Errors
+			if strict {
+				blocking = append(blocking, result.Warnings...)
+			}
+
+			for _, msg := range blocking {
+				fmt.Println(msg)
+			}
+
+			if len(blocking) > 0 {
+				fmt.Printf("%s %s (%d blocking)\n", term.Red("FAIL"), path, len(blocking))
+				os.Exit(1)
+			}
+
+			fmt.Printf("%s %s\n", term.Green("PASS"), path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&strict, "strict", false, "Treat warnings as errors")
+	return cmd
+}
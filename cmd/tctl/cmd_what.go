@@ -8,18 +8,41 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/freshness"
 	"github.com/yourname/tctl/internal/scanner"
 	"github.com/yourname/tctl/internal/util"
 	"github.com/yourname/tctl/pkg/tool"
 )
 
 func whatCmd() *cobra.Command {
-	return &cobra.Command{
+	var getView bool
+	var noStem bool
+	var languages []string
+	var dataOnly, keywordsOnly bool
+	var top int
+
+	cmd := &cobra.Command{
 		Use:   "what",
 		Short: "Show what's available (dynamic from tool metadata)",
 		Long: `Scans all registered sources and displays:
   - Available data (what you can 'tctl get')
-  - Common keywords for searching`,
+  - Common keywords for searching
+
+Keywords that are forms of the same word (parse, parses, parsing) are
+counted together under the shortest form; --no-stem lists them
+separately.
+
+--data-only and --keywords-only print just one of the two sections;
+--top sets how many keywords are listed (0 for all).
+
+With --get, shows only the data, grouped by whether 'tctl get' would
+regenerate it: fresh, stale, missing, or always (no @output to check).
+
+Examples:
+  tctl what                           # Data and keywords
+  tctl what --get                     # What 'tctl get' would regenerate right now
+  tctl what --keywords-only --top 50  # The 50 most common keywords
+  tctl what --language python`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -38,72 +61,158 @@ func whatCmd() *cobra.Command {
 				return err
 			}
 
-			tools := registry.All()
+			warnUnknownLanguages(languages)
+
+			var tools []*tool.Tool
+			for _, t := range registry.All() {
+				if hasLanguage(t, languages) {
+					tools = append(tools, t)
+				}
+			}
 			if len(tools) == 0 {
 				fmt.Println("No tools found.")
 				return nil
 			}
 
-			// Print available data
-			fmt.Println()
-			fmt.Println("📊 DATA AVAILABLE:")
-			fmt.Println()
+			if getView {
+				printGettableData(tools)
+				return nil
+			}
+
+			if !keywordsOnly {
+				// Print available data
+				fmt.Println()
+				fmt.Println("📊 DATA AVAILABLE:")
+				fmt.Println()
 
-			for _, t := range tools {
-				for _, p := range t.Provides {
-					fmt.Printf("  %-24s → tctl get %s\n", p, p)
+				for _, t := range tools {
+					for _, p := range t.Provides {
+						fmt.Printf("  %-24s → tctl get %s\n", p, p)
+					}
 				}
 			}
 
-			// Build and print keywords
-			keywordMap := buildKeywordMap(tools)
-			printKeywords(keywordMap)
+			if !dataOnly {
+				// Build and print keywords
+				keywordMap := buildKeywordMap(tools, cfg.StopWords, !noStem)
+				printKeywords(keywordMap, top)
 
-			fmt.Println()
-			fmt.Println("Run 'tctl find <keyword>' for specific matching")
+				fmt.Println()
+				fmt.Println("Run 'tctl find <keyword>' for specific matching")
+			}
 			fmt.Println()
 
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&getView, "get", false, "Show data grouped by freshness")
+	cmd.Flags().StringArrayVar(&languages, "language", nil, "Only tools in this language (repeatable)")
+	cmd.Flags().BoolVar(&noStem, "no-stem", false, "Count each form of a keyword separately")
+	cmd.Flags().BoolVar(&dataOnly, "data-only", false, "Show only the available data")
+	cmd.Flags().BoolVar(&keywordsOnly, "keywords-only", false, "Show only the keywords")
+	cmd.Flags().IntVar(&top, "top", 15, "Number of keywords to show (0 for all)")
+	cmd.MarkFlagsMutuallyExclusive("data-only", "keywords-only", "get")
+	return cmd
+}
+
+// printGettableData prints every provided artifact grouped by the
+// freshness of its tool's output.
+func printGettableData(tools []*tool.Tool) {
+	groups := []struct {
+		title string
+		items []string
+	}{
+		{title: "✓ FRESH:"},
+		{title: "⚠ STALE:"},
+		{title: "✗ MISSING:"},
+		{title: "↻ ALWAYS RUN (no @output):"},
+	}
+
+	for _, t := range tools {
+		group, status := 3, ""
+		if t.Output != "" {
+			fresh, msg := freshness.Check(t.OutputPath(), t.Freshness)
+			switch {
+			case fresh:
+				group = 0
+			case msg == "missing":
+				group = 2
+			default:
+				group = 1
+			}
+			status = msg
+		}
+		for _, p := range t.Provides {
+			line := fmt.Sprintf("  %-24s → tctl get %s", p, p)
+			if status != "" && status != "missing" {
+				line += "  " + status
+			}
+			groups[group].items = append(groups[group].items, line)
+		}
+	}
+
+	fmt.Println()
+	for _, g := range groups {
+		if len(g.items) == 0 {
+			continue
+		}
+		fmt.Println(g.title)
+		fmt.Println()
+		for _, item := range g.items {
+			fmt.Println(item)
+		}
+		fmt.Println()
+	}
 }
 
-// buildKeywordMap builds a map of keywords to tool names.
-func buildKeywordMap(tools []*tool.Tool) map[string][]string {
+// buildKeywordMap builds a map of keywords to tool names, leaving out
+// stopWords from capabilities. With stem, keywords with the same stem are
+// merged under their shortest form.
+func buildKeywordMap(tools []*tool.Tool, stopWords map[string]bool, stem bool) map[string][]string {
 	keywordMap := make(map[string]map[string]bool)
+	forms := make(map[string]string) // key -> keyword shown for it
+
+	add := func(word, toolName string) {
+		key := word
+		if stem {
+			key = util.Stem(word)
+		}
+		if form, ok := forms[key]; !ok || len(word) < len(form) || len(word) == len(form) && word < form {
+			forms[key] = word
+		}
+		if keywordMap[key] == nil {
+			keywordMap[key] = make(map[string]bool)
+		}
+		keywordMap[key][toolName] = true
+	}
 
 	for _, t := range tools {
 		for _, kw := range t.Keywords {
-			kw = strings.ToLower(kw)
-			if keywordMap[kw] == nil {
-				keywordMap[kw] = make(map[string]bool)
-			}
-			keywordMap[kw][t.Name] = true
+			add(strings.ToLower(kw), t.Name)
 		}
 		for _, cap := range t.Capabilities {
-			for _, word := range util.ExtractKeywords(cap) {
-				if keywordMap[word] == nil {
-					keywordMap[word] = make(map[string]bool)
-				}
-				keywordMap[word][t.Name] = true
+			for _, word := range util.ExtractKeywords(cap, stopWords) {
+				add(word, t.Name)
 			}
 		}
 	}
 
 	// Convert to string slices
 	result := make(map[string][]string)
-	for kw, toolSet := range keywordMap {
+	for key, toolSet := range keywordMap {
 		var toolNames []string
 		for name := range toolSet {
 			toolNames = append(toolNames, name)
 		}
-		result[kw] = toolNames
+		result[forms[key]] = toolNames
 	}
 	return result
 }
 
-// printKeywords prints the top keywords sorted by frequency.
-func printKeywords(keywordMap map[string][]string) {
+// printKeywords prints the top keywords sorted by frequency, or all of
+// them if top is 0.
+func printKeywords(keywordMap map[string][]string, top int) {
 	type kwCount struct {
 		kw    string
 		tools []string
@@ -121,7 +230,7 @@ func printKeywords(keywordMap map[string][]string) {
 	fmt.Println()
 
 	for i, item := range sorted {
-		if i >= 15 {
+		if top > 0 && i >= top {
 			break
 		}
 		toolsStr := strings.Join(item.tools[:util.Min(2, len(item.tools))], ", ")
@@ -9,19 +9,41 @@ import (
 
 	"github.com/yourname/tctl/internal/config"
 	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/util"
 	"github.com/yourname/tctl/pkg/tool"
 )
 
+// whereCreateThreshold is the score at which 'tctl where --create'
+// considers an existing tool a good match and refuses to scaffold.
+const whereCreateThreshold = 10
+
 func whereCmd() *cobra.Command {
-	return &cobra.Command{
+	var create bool
+	var outputDir string
+	var caseSensitive, wholeWord, noStem bool
+	var paging pageFlags
+
+	cmd := &cobra.Command{
 		Use:   "where <feature>",
 		Short: "Suggest where a feature should go",
 		Long: `Analyzes existing tools to suggest where a new feature belongs.
 Searches tool names, descriptions, capabilities, and keywords.
 
+With --create, scaffolds a new tool for the feature when no existing
+tool is a good match. If one is, the matches are shown instead.
+
+--word matches the feature's words only as whole words, and
+--case-sensitive requires exact case (by default, words match anywhere,
+ignoring case). Words also match other forms of the same word, so
+"parse" finds "parsing"; --no-stem turns this off.
+
+The 5 best matches are shown. --page and --page-size page through all
+of them instead.
+
 Examples:
-  tctl where "jira summary"    # Where should jira summaries go?
-  tctl where "parse logs"      # Which tool handles log parsing?`,
+  tctl where "jira summary"                   # Where should jira summaries go?
+  tctl where "parse logs"                     # Which tool handles log parsing?
+  tctl where "parse logs" --create -o ~/tools # Create parse-logs if nothing fits`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
@@ -29,21 +51,38 @@ Examples:
 				return err
 			}
 
+			feature := strings.Join(args, " ")
+
+			var tools []*tool.Tool
 			paths := cfg.SourcePaths()
 			if len(paths) == 0 {
-				fmt.Println("No sources registered.")
-				return nil
-			}
-
-			registry, err := scanner.ScanDirectories(paths)
-			if err != nil {
-				return err
+				if !create {
+					fmt.Println("No sources registered.")
+					return nil
+				}
+			} else {
+				registry, err := scanner.ScanDirectories(paths)
+				if err != nil {
+					return err
+				}
+				tools = registry.All()
 			}
 
-			feature := strings.Join(args, " ")
-			tools := registry.All()
+			matches, excluded := analyzeFeaturePlacement(tools, feature, cfg.Synonyms, util.NewMatcher(caseSensitive, wholeWord, !noStem))
 
-			matches, excluded := analyzeFeaturePlacement(tools, feature)
+			if create {
+				goodMatch := false
+				for _, m := range matches {
+					if m.score >= whereCreateThreshold {
+						goodMatch = true
+						break
+					}
+				}
+				if !goodMatch {
+					return createTool(suggestToolName(feature), defaultLanguage(cfg, outputDir), outputDir,
+						featureDescription(feature), util.ExtractKeywords(feature, cfg.StopWords))
+				}
+			}
 
 			fmt.Println()
 			fmt.Printf("# Where should '%s' go?\n", feature)
@@ -55,18 +94,33 @@ Examples:
 					return matches[i].score > matches[j].score
 				})
 
-				fmt.Println("## Best matches\n")
-				for i, m := range matches {
-					if i >= 5 {
-						break
+				fmt.Println("## Best matches")
+				fmt.Println()
+				if paging.enabled() {
+					start, end, pages, err := paging.bounds(len(matches))
+					if err != nil {
+						return err
+					}
+					for _, m := range matches[start:end] {
+						printWhereMatch(m)
+					}
+					paging.printPageFooter(pages)
+					fmt.Println()
+				} else {
+					for i, m := range matches {
+						if i >= 5 {
+							break
+						}
+						printWhereMatch(m)
 					}
-					printWhereMatch(m)
 				}
 			}
 
 			if len(excluded) > 0 {
-				fmt.Println("## Explicitly excluded\n")
-				fmt.Println("These tools have @boundary tags that exclude this feature:\n")
+				fmt.Println("## Explicitly excluded")
+				fmt.Println()
+				fmt.Println("These tools have @boundary tags that exclude this feature:")
+				fmt.Println()
 				for i, e := range excluded {
 					if i >= 3 {
 						break
@@ -76,17 +130,49 @@ Examples:
 				fmt.Println()
 			}
 
+			if create {
+				fmt.Println("Not creating a new tool: existing tools already match this feature.")
+				fmt.Printf("Extend one of them, or run 'tctl new %s' to create one anyway.\n", suggestToolName(feature))
+				return nil
+			}
+
 			if len(matches) == 0 {
-				featureWords := strings.Fields(strings.ToLower(feature))
-				suggestedName := strings.Join(featureWords[:min(3, len(featureWords))], "-")
-				fmt.Println("No existing tool matches this feature.\n")
+				fmt.Println("No existing tool matches this feature.")
+				fmt.Println()
 				fmt.Println("Create a new tool:")
-				fmt.Printf("```bash\ntctl new %s\n```\n", suggestedName)
+				fmt.Printf("```bash\ntctl new %s\n```\n", suggestToolName(feature))
 			}
 
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&create, "create", false, "Create a new tool for the feature if nothing matches")
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "", "Output directory for --create")
+	cmd.Flags().BoolVar(&wholeWord, "word", false, "Match words only at word boundaries")
+	cmd.Flags().BoolVar(&caseSensitive, "case-sensitive", false, "Match words with exact case")
+	cmd.Flags().BoolVar(&noStem, "no-stem", false, "Don't match other forms of a word (parse, parsing, parsed)")
+	addPageFlags(cmd, &paging)
+	return cmd
+}
+
+// suggestToolName derives a tool name from the first words of a feature.
+func suggestToolName(feature string) string {
+	featureWords := strings.Fields(strings.ToLower(feature))
+	return strings.Join(featureWords[:min(3, len(featureWords))], "-")
+}
+
+// featureDescription turns a feature into a one-line tool description.
+func featureDescription(feature string) string {
+	desc := strings.TrimSpace(feature)
+	if desc == "" {
+		return ""
+	}
+	desc = strings.ToUpper(desc[:1]) + desc[1:]
+	if !strings.HasSuffix(desc, ".") {
+		desc += "."
+	}
+	return desc
 }
 
 type featureMatch struct {
@@ -96,60 +182,55 @@ type featureMatch struct {
 	score   int
 }
 
-func analyzeFeaturePlacement(tools []*tool.Tool, feature string) (matches, excluded []featureMatch) {
-	terms := strings.Fields(strings.ToLower(feature))
+func analyzeFeaturePlacement(tools []*tool.Tool, feature string, synonyms map[string][]string, matcher *util.Matcher) (matches, excluded []featureMatch) {
+	terms := util.ExpandTerms(strings.Fields(feature), synonyms)
 
 	for _, t := range tools {
 		var reasons []string
 		score := 0
 
 		// Check tool name (highest weight)
-		nameLower := strings.ToLower(t.Name)
 		for _, term := range terms {
-			if strings.Contains(nameLower, term) {
-				score += 10
-				reasons = append(reasons, fmt.Sprintf("name contains '%s'", term))
+			if matcher.Contains(t.Name, term.Text) {
+				score += term.Score(10)
+				reasons = append(reasons, term.Reason(fmt.Sprintf("name contains '%s'", term.Text)))
 			}
 		}
 
 		// Check description
-		descLower := strings.ToLower(t.Description)
 		for _, term := range terms {
-			if strings.Contains(descLower, term) {
-				score += 5
-				reasons = append(reasons, fmt.Sprintf("description mentions '%s'", term))
+			if matcher.Contains(t.Description, term.Text) {
+				score += term.Score(5)
+				reasons = append(reasons, term.Reason(fmt.Sprintf("description mentions '%s'", term.Text)))
 			}
 		}
 
 		// Check provides
 		for _, p := range t.Provides {
-			pLower := strings.ToLower(p)
 			for _, term := range terms {
-				if strings.Contains(pLower, term) {
-					score += 6
-					reasons = append(reasons, fmt.Sprintf("provides '%s'", p))
+				if matcher.Contains(p, term.Text) {
+					score += term.Score(6)
+					reasons = append(reasons, term.Reason(fmt.Sprintf("provides '%s'", p)))
 				}
 			}
 		}
 
 		// Check capabilities (good indicator for feature placement)
 		for _, cap := range t.Capabilities {
-			capLower := strings.ToLower(cap)
 			for _, term := range terms {
-				if strings.Contains(capLower, term) {
-					score += 4
-					reasons = append(reasons, fmt.Sprintf("capability: %s", cap))
+				if matcher.Contains(cap, term.Text) {
+					score += term.Score(4)
+					reasons = append(reasons, term.Reason(fmt.Sprintf("capability: %s", cap)))
 				}
 			}
 		}
 
 		// Check keywords
 		for _, kw := range t.Keywords {
-			kwLower := strings.ToLower(kw)
 			for _, term := range terms {
-				if strings.Contains(kwLower, term) || strings.Contains(term, kwLower) {
-					score += 3
-					reasons = append(reasons, fmt.Sprintf("keyword '%s'", kw))
+				if matcher.Contains(kw, term.Text) || matcher.Contains(term.Text, kw) {
+					score += term.Score(3)
+					reasons = append(reasons, term.Reason(fmt.Sprintf("keyword '%s'", kw)))
 				}
 			}
 		}
@@ -164,9 +245,8 @@ func analyzeFeaturePlacement(tools []*tool.Tool, feature string) (matches, exclu
 
 		// Check boundaries (negative match)
 		for _, boundary := range t.Boundaries {
-			boundaryLower := strings.ToLower(boundary)
 			for _, term := range terms {
-				if strings.Contains(boundaryLower, term) {
+				if matcher.Contains(boundary, term.Text) {
 					excluded = append(excluded, featureMatch{
 						tool:   t,
 						reason: boundary,
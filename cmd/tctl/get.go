@@ -1,31 +1,155 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/yourname/tctl/internal/config"
 	"github.com/yourname/tctl/internal/freshness"
+	"github.com/yourname/tctl/internal/lock"
+	"github.com/yourname/tctl/internal/runlog"
 	"github.com/yourname/tctl/internal/runner"
 	"github.com/yourname/tctl/internal/scanner"
 	"github.com/yourname/tctl/pkg/tool"
 )
 
+// getOptions controls how ensureData treats freshness and which
+// arguments the planned tools get.
+type getOptions struct {
+	force     bool   // regenerate the target even if its output is fresh
+	forceDeps bool   // also regenerate the target's dependencies
+	profile   bool   // time each tool and summarize the slowest
+	format    string // passed as --format to the tools providing the targets
+	noWait    bool   // fail instead of waiting for a tool another process is running
+	keepGoing bool   // after a failure, carry on with whatever doesn't depend on it
+	outputDir string // base for relative @output paths and the tools' working directory
+
+	// noOutputCheck skips checking that a tool which exits 0 wrote its
+	// @output.
+	noOutputCheck bool
+
+	// measureOutput reports the size and rows of each output a tool
+	// writes, and how they changed.
+	measureOutput bool
+
+	// log receives tctl's progress messages and the tools' stdout. It is
+	// stderr with --print-output-path, leaving stdout for the paths.
+	log io.Writer
+}
+
+// outputDirEnv is the default for --output-dir. It is also set for tools
+// run with an output directory, so they can find it.
+const outputDirEnv = "TCTL_OUTPUT_DIR"
+
+// resolveOutputDir makes an --output-dir value absolute. Empty stays empty.
+func resolveOutputDir(dir string) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("--output-dir: %w", err)
+	}
+	return abs, nil
+}
+
+// absOutputPath returns the absolute path of t's @output, resolved
+// against outputDir if it is set. For a glob @output it is the newest
+// match.
+func absOutputPath(t *tool.Tool, outputDir string) (string, error) {
+	path := t.OutputPathIn(outputDir)
+	if freshness.IsGlob(path) {
+		newest, err := freshness.Newest(path)
+		if err != nil {
+			return "", fmt.Errorf("no file matches %s", path)
+		}
+		path = newest
+	}
+	return filepath.Abs(path)
+}
+
 func getCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "get <data>",
+	var opts getOptions
+	var jobs int
+	var printOutputPath bool
+
+	cmd := &cobra.Command{
+		Use:   "get <data>...",
 		Short: "Ensure data exists, running tools if needed",
 		Long: `Ensures that the specified data is up-to-date.
 Resolves dependencies, checks freshness, and runs tools if necessary.
+With several targets, shared dependencies are evaluated only once.
+
+Tools that don't depend on each other can run in parallel with --jobs.
+Their output is then prefixed with the tool name. Tools marked
+"@concurrency-safe false" run one at a time even then, though other
+tools may run alongside them.
+
+A tool is never run by two tctl processes at once. If another process is
+already running a tool, get waits for it and then skips the tool if its
+output is now fresh; with --no-wait it fails instead. Lock files live in
+the locks/ directory of the config directory and are released when their
+holder exits, even if it crashes.
+
+By default get stops at the first failure. With --keep-going it carries
+on with everything that doesn't depend on the failed data or tool, then
+lists all failures and exits with status 1.
+
+--output-dir (default $TCTL_OUTPUT_DIR) resolves relative @output paths
+against another directory, such as a scratch directory in CI, instead of
+the tool's source root. Tools then run with it as their working
+directory, and with TCTL_OUTPUT_DIR set to it, so they write there too.
+Absolute @output paths are unaffected.
+
+A tool that exits 0 but leaves its @output missing or unchanged counts
+as failed, since a silent no-op would otherwise pass for fresh data.
+--no-output-check turns this off, for tools whose output legitimately
+doesn't change on every run.
+
+With --print-output-path, get prints the absolute path of each target's
+@output on stdout once everything has succeeded, one per line, and
+nothing else: its own messages and the tools' output go to stderr. Use
+it to hand fresh data to the next command. Targets must be data with an
+@output, not intents.
+
+With --measure-output, each regenerated output is reported with its
+size and, for CSV, TSV, and other line-based text files, its row or line
+count, each with the change since before the run. Only single files are
+measured, not directories or glob patterns.
+
+--format is passed through to the tools that produce the requested data
+when they run. Those tools must declare a --format argument in their
+@interface. Fresh data is regenerated when --format differs from the
+tool's @output-format, since the existing file is in the default format.
 
 Examples:
-  tctl get prices        # Ensure prices data exists
-  tctl get signals       # Runs fetch-prices first if needed`,
-		Args: cobra.ExactArgs(1),
+  tctl get prices                   # Ensure prices data exists
+  tctl get signals                  # Runs fetch-prices first if needed
+  tctl get prices signals report    # Ensure several targets in one go
+  tctl get signals --force          # Rerun compute-signals even if fresh
+  tctl get signals --force-deps     # Also rerun fetch-prices
+  tctl get report --jobs 4          # Run up to 4 independent tools at once
+  tctl get report --profile         # Time each tool that runs
+  tctl get daily --keep-going       # Refresh as much of an intent as possible
+  tctl get prices --format json     # Regenerate prices as JSON
+  tctl get prices -f --measure-output  # "→ output: ... (1,240 rows, +37; ...)"
+  tctl get report --output-dir /tmp/ci  # Check and write outputs under /tmp/ci
+  f=$(tctl get prices --print-output-path)  # Path of fresh prices data`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if jobs < 1 {
+				return fmt.Errorf("--jobs must be at least 1")
+			}
+
 			cfg, err := config.Load()
 			if err != nil {
 				return err
@@ -37,89 +161,655 @@ Examples:
 				return nil
 			}
 
-			target := args[0]
-			fmt.Printf("[tctl] ensuring: %s\n", target)
-
 			registry, err := scanner.ScanDirectories(paths)
 			if err != nil {
 				return err
 			}
 
-			visited := make(map[string]bool)
-			success := ensureData(target, cfg, registry, visited)
-			if success {
-				fmt.Println("[tctl] ✓ done")
+			if opts.forceDeps {
+				opts.force = true
+			}
+			if opts.outputDir, err = resolveOutputDir(opts.outputDir); err != nil {
+				return err
+			}
+			if opts.outputDir != "" {
+				if err := os.MkdirAll(opts.outputDir, 0755); err != nil {
+					return err
+				}
+			}
+
+			// Check up front that every target has a path to print
+			opts.log = os.Stdout
+			if printOutputPath {
+				for _, target := range args {
+					if _, ok := cfg.GetIntent(target); ok {
+						return fmt.Errorf("--print-output-path needs data, but %s is an intent", target)
+					}
+					if t, _ := registry.FindByProvides(target); t != nil && t.Output == "" {
+						return fmt.Errorf("--print-output-path: %s, which provides %s, has no @output", t.Name, target)
+					}
+				}
+				opts.log = os.Stderr
+			}
+
+			plan := newGetPlan()
+			for _, target := range args {
+				fmt.Fprintf(opts.log, "[tctl] ensuring: %s\n", target)
+				ensureData(target, cfg, registry, plan, opts)
+			}
+
+			plan.execute(jobs, opts)
+			if opts.profile {
+				plan.printProfile()
+			}
+			if opts.keepGoing {
+				plan.printFailures()
+			}
+
+			var failed []string
+			for _, target := range args {
+				if !plan.succeeded(target) {
+					failed = append(failed, target)
+				}
+			}
+
+			if len(args) > 1 {
+				fmt.Fprintln(opts.log, "[tctl] summary:")
+				for _, target := range args {
+					if plan.succeeded(target) {
+						fmt.Fprintf(opts.log, "  ✓ %s\n", target)
+					} else {
+						fmt.Fprintf(opts.log, "  ✗ %s\n", target)
+					}
+				}
+			}
+
+			if len(failed) == 0 {
+				fmt.Fprintln(opts.log, "[tctl] ✓ done")
 			} else {
-				fmt.Println("[tctl] ✗ failed")
+				fmt.Fprintln(opts.log, "[tctl] ✗ failed")
 				os.Exit(1)
 			}
 
+			if printOutputPath {
+				for _, target := range args {
+					t, _ := registry.FindByProvides(target)
+					path, err := absOutputPath(t, opts.outputDir)
+					if err != nil {
+						return err
+					}
+					fmt.Println(path)
+				}
+			}
+
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Run the tool even if its data is fresh")
+	cmd.Flags().BoolVar(&opts.forceDeps, "force-deps", false, "Also rerun all dependencies (implies --force)")
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", 1, "Number of independent tools to run in parallel")
+	cmd.Flags().BoolVar(&opts.profile, "profile", false, "Report how long each tool took")
+	cmd.Flags().StringVar(&opts.format, "format", "", "Output format to pass to the target tools (e.g. csv, json)")
+	cmd.Flags().BoolVar(&opts.noWait, "no-wait", false, "Fail instead of waiting when another tctl is running a tool")
+	cmd.Flags().BoolVarP(&opts.keepGoing, "keep-going", "k", false, "Continue past failures and report them all at the end")
+	cmd.Flags().StringVar(&opts.outputDir, "output-dir", os.Getenv(outputDirEnv), "Resolve relative @output paths here and run tools in it")
+	cmd.Flags().BoolVar(&opts.measureOutput, "measure-output", false, "Report each output's size and row count, and how they changed")
+	cmd.Flags().BoolVar(&printOutputPath, "print-output-path", false, "Print only the targets' absolute output paths on stdout")
+	cmd.Flags().BoolVar(&opts.noOutputCheck, "no-output-check", false, "Don't fail tools that exit 0 without writing their @output")
+	return cmd
 }
 
-func ensureData(target string, cfg *config.Global, registry *tool.Registry, visited map[string]bool) bool {
-	if visited[target] {
-		return true // Already processed
+// stepStatus is the execution state of a planStep.
+type stepStatus int
+
+const (
+	stepPending stepStatus = iota
+	stepOK
+	stepFailed
+	stepSkipped // not run because a dependency failed
+)
+
+// planStep is a single tool run scheduled by tctl get.
+type planStep struct {
+	tool     *tool.Tool
+	deps     []*planStep
+	level    int      // 0 for steps with no dependencies in the plan
+	args     []string // arguments passed to the tool
+	force    bool     // run even if the output became fresh while waiting for the lock
+	status   stepStatus
+	duration time.Duration // wall-clock time of the run, once it has run
+}
+
+// getPlan is the set of tools a get invocation needs to run.
+// Building the plan resolves dependencies and checks freshness;
+// execute then runs the steps level by level.
+type getPlan struct {
+	steps    []*planStep            // in dependency order
+	byTool   map[string]*planStep   // one step per tool, even if it provides several targets
+	resolved map[string]bool        // outcome of resolving each target
+	targets  map[string][]*planStep // steps each resolved target needs directly
+}
+
+func newGetPlan() *getPlan {
+	return &getPlan{
+		byTool:   make(map[string]*planStep),
+		resolved: make(map[string]bool),
+		targets:  make(map[string][]*planStep),
 	}
-	visited[target] = true
+}
+
+// ensureData adds whatever is needed to bring target up-to-date to the plan.
+// It checks freshness and resolves dependencies but does not run anything.
+// Each target is resolved at most once; the outcome is recorded in the plan.
+// It normally gives up at the first problem; with opts.keepGoing it goes on
+// to resolve the rest of an intent or a tool's dependencies first.
+func ensureData(target string, cfg *config.Global, registry *tool.Registry, plan *getPlan, opts getOptions) (ok bool) {
+	if done, seen := plan.resolved[target]; seen {
+		return done // Already processed (or in progress)
+	}
+	plan.resolved[target] = true
+	defer func() { plan.resolved[target] = ok }()
 
 	// Check if it's an intent
 	if intent, ok := cfg.GetIntent(target); ok {
-		fmt.Printf("[tctl] intent: %s\n", target)
+		fmt.Fprintf(opts.log, "[tctl] intent: %s\n", target)
+		allOK := true
 		for _, item := range intent.Includes {
-			if !ensureData(item, cfg, registry, visited) {
-				return false
+			if !ensureData(item, cfg, registry, plan, opts) {
+				if !opts.keepGoing {
+					return false
+				}
+				allOK = false
+				continue
 			}
+			plan.targets[target] = append(plan.targets[target], plan.targets[item]...)
 		}
-		return true
+		return allOK
 	}
 
 	// Find tool that provides this data
-	t := registry.FindByProvides(target)
+	t, err := registry.FindByProvides(target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[tctl] ✗ %v\n", err)
+		return false
+	}
 	if t == nil {
 		fmt.Fprintf(os.Stderr, "[tctl] ✗ Unknown data: %s\n", target)
 		fmt.Fprintf(os.Stderr, "       No tool provides '%s'\n", target)
+		if hint := didYouMean(target, artifactNames(registry)); hint != "" {
+			fmt.Fprintf(os.Stderr, "       %s\n", hint)
+		}
 		return false
 	}
 
-	// Check freshness
-	if t.Output != "" {
-		outputPath := t.Output
-		if !filepath.IsAbs(outputPath) {
-			outputPath = filepath.Join(filepath.Dir(t.File), "..", t.Output)
+	var args []string
+	if opts.format != "" {
+		if args, err = formatArgs(t, opts.format); err != nil {
+			fmt.Fprintf(os.Stderr, "[tctl] ✗ %v\n", err)
+			return false
+		}
+	}
+
+	if step := plan.byTool[t.Name]; step != nil {
+		if step.args == nil {
+			step.args = args
 		}
+		plan.targets[target] = []*planStep{step}
+		return true
+	}
 
-		fresh, msg := freshness.Check(outputPath, t.Freshness)
-		if fresh {
-			fmt.Printf("[tctl] ✓ %s: %s\n", target, msg)
+	// Check freshness. Fresh output in the tool's default format doesn't
+	// satisfy a request for another format.
+	reformat := opts.format != "" && opts.format != t.OutputFormat
+	if t.Output != "" {
+		fresh, msg := freshness.Check(t.OutputPathIn(opts.outputDir), t.Freshness)
+		switch {
+		case fresh && !opts.force && !reformat:
+			fmt.Fprintf(opts.log, "[tctl] ✓ %s: %s\n", target, msg)
 			return true
+		case fresh && !opts.force:
+			fmt.Fprintf(opts.log, "[tctl] → %s: %s, but --format %s was requested, regenerating...\n", target, msg, opts.format)
+		case fresh:
+			fmt.Fprintf(opts.log, "[tctl] → %s: %s, forcing...\n", target, msg)
+		default:
+			fmt.Fprintf(opts.log, "[tctl] → %s: %s, regenerating...\n", target, msg)
 		}
-		fmt.Printf("[tctl] → %s: %s, regenerating...\n", target, msg)
 	}
 
 	// Ensure dependencies first
+	step := &planStep{tool: t, args: args, force: opts.force || reformat}
+	depOpts := opts
+	depOpts.force = opts.forceDeps
+	depOpts.format = "" // only the requested data changes format
+	depsOK := true
 	for _, dep := range t.Requires {
-		if !ensureData(dep, cfg, registry, visited) {
+		if err := checkConstraint(t, dep, registry); err != nil {
+			fmt.Fprintf(os.Stderr, "[tctl] ✗ %v\n", err)
+			if !opts.keepGoing {
+				return false
+			}
+			depsOK = false
+			continue
+		}
+		if !ensureData(dep, cfg, registry, plan, depOpts) {
+			if !opts.keepGoing {
+				return false
+			}
+			depsOK = false
+			continue
+		}
+		for _, d := range plan.targets[dep] {
+			step.deps = append(step.deps, d)
+			if d.level >= step.level {
+				step.level = d.level + 1
+			}
+		}
+	}
+	if !depsOK {
+		return false
+	}
+
+	plan.steps = append(plan.steps, step)
+	plan.byTool[t.Name] = step
+	plan.targets[target] = []*planStep{step}
+	return true
+}
+
+// formatArgs returns the arguments that make t write its output in format.
+// t must declare a --format argument that accepts it.
+func formatArgs(t *tool.Tool, format string) ([]string, error) {
+	arg, ok := t.Interface["--format"]
+	if !ok {
+		return nil, fmt.Errorf("%s has no --format argument in its @interface; can't pass --format %s", t.Name, format)
+	}
+	if !arg.AllowsValue(format) {
+		return nil, fmt.Errorf("%s --format must be one of %s, not %s", t.Name, strings.Join(arg.Choices, "|"), format)
+	}
+	return []string{"--format", format}, nil
+}
+
+// checkConstraint verifies that the provider of dep satisfies any version
+// constraint t declares on it (e.g. "@requires prices>=1.2").
+func checkConstraint(t *tool.Tool, dep string, registry *tool.Registry) error {
+	c := t.Constraint(dep)
+	if c == nil {
+		return nil
+	}
+	provider, err := registry.FindByProvides(dep)
+	if err != nil {
+		return err
+	}
+	if provider == nil || c.SatisfiedBy(provider.Version) {
+		return nil
+	}
+	if provider.Version == "" {
+		return fmt.Errorf("%s requires %s, but %s declares no @version", t.Name, c, provider.Name)
+	}
+	return fmt.Errorf("%s requires %s, but %s is version %s", t.Name, c, provider.Name, provider.Version)
+}
+
+// execute runs the planned steps. Steps on the same level don't depend on
+// each other and run up to jobs at a time, though concurrency-unsafe tools
+// never overlap with each other. After a failure, no new level
+// is started, unless opts.keepGoing is set: then only the steps that depend
+// on a failed one are skipped. With opts.profile, each step's duration is
+// reported as it ends.
+func (p *getPlan) execute(jobs int, opts getOptions) {
+	maxLevel := -1
+	for _, s := range p.steps {
+		if s.level > maxLevel {
+			maxLevel = s.level
+		}
+	}
+
+	// Held while a concurrency-unsafe tool runs, so they run one at a time
+	var unsafeLane sync.Mutex
+
+	parallel := jobs > 1
+	for level := 0; level <= maxLevel; level++ {
+		var ready []*planStep
+		for _, s := range p.steps {
+			if s.level != level {
+				continue
+			}
+			if !s.depsOK() {
+				s.status = stepSkipped
+				continue
+			}
+			ready = append(ready, s)
+		}
+
+		sem := make(chan struct{}, jobs)
+		var wg sync.WaitGroup
+		for _, s := range ready {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(s *planStep) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				l, run := lockStep(s, parallel, opts)
+				if !run {
+					return
+				}
+				if l != nil {
+					defer l.Release()
+				}
+				if s.tool.ConcurrencyUnsafe {
+					unsafeLane.Lock()
+					defer unsafeLane.Unlock()
+				}
+
+				res, ok := runStep(s.tool, s.args, parallel, opts)
+				s.duration = res.Duration
+				if opts.profile {
+					_, stderr := logWriters(parallel, opts)
+					printProfile(stderr, s.tool.Name, res)
+				}
+				if ok {
+					s.status = stepOK
+				} else {
+					s.status = stepFailed
+				}
+			}(s)
+		}
+		wg.Wait()
+
+		if opts.keepGoing {
+			continue
+		}
+		for _, s := range ready {
+			if s.status == stepFailed {
+				p.skipPending()
+				return
+			}
+		}
+	}
+}
+
+// depsOK reports whether every step s depends on ran successfully.
+func (s *planStep) depsOK() bool {
+	for _, d := range s.deps {
+		if d.status != stepOK {
 			return false
 		}
 	}
+	return true
+}
+
+// printFailures lists the targets that could not be resolved and the steps
+// that failed or were skipped because a dependency failed.
+func (p *getPlan) printFailures() {
+	var unresolved []string
+	for target, ok := range p.resolved {
+		if !ok {
+			unresolved = append(unresolved, target)
+		}
+	}
+	sort.Strings(unresolved)
+
+	var lines []string
+	for _, target := range unresolved {
+		lines = append(lines, fmt.Sprintf("  ✗ %s: could not be resolved", target))
+	}
+	for _, s := range p.steps {
+		switch s.status {
+		case stepFailed:
+			lines = append(lines, fmt.Sprintf("  ✗ %s: failed", s.tool.Name))
+		case stepSkipped:
+			lines = append(lines, fmt.Sprintf("  - %s: skipped (a dependency failed)", s.tool.Name))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
 
-	// Run the tool
-	exitCode, err := runner.Run(t, nil)
+	fmt.Fprintln(os.Stderr, "[tctl] failures:")
+	for _, line := range lines {
+		fmt.Fprintln(os.Stderr, line)
+	}
+}
+
+// lockStep takes the step's tool lock so no other tctl process runs the
+// tool at the same time. It reports false if the step shouldn't run: the
+// tool is busy and opts.noWait is set, or another process made the output
+// fresh while this one waited. The step's status is then already set.
+// Locking is best-effort; if the lock can't be taken, the tool runs
+// without it.
+func lockStep(s *planStep, parallel bool, opts getOptions) (*lock.Lock, bool) {
+	stdout, stderr := logWriters(parallel, opts)
+	l, err := lock.TryAcquire(s.tool.Name)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "[tctl] ✗ %s: %v\n", t.Name, err)
-		return false
+		fmt.Fprintf(stderr, "[tctl] ⚠ could not lock %s: %v\n", s.tool.Name, err)
+		return nil, true
+	}
+	if l != nil {
+		return l, true
+	}
+
+	if opts.noWait {
+		fmt.Fprintf(stderr, "[tctl] ✗ %s is already running in another tctl process\n", s.tool.Name)
+		s.status = stepFailed
+		return nil, false
+	}
+
+	fmt.Fprintf(stdout, "[tctl] waiting for another tctl process running %s...\n", s.tool.Name)
+	if l, err = lock.Acquire(s.tool.Name); err != nil {
+		fmt.Fprintf(stderr, "[tctl] ⚠ could not lock %s: %v\n", s.tool.Name, err)
+		return nil, true
+	}
+
+	// The other process has most likely just regenerated the output
+	if !s.force && s.tool.Output != "" {
+		if fresh, msg := freshness.Check(s.tool.OutputPathIn(opts.outputDir), s.tool.Freshness); fresh {
+			fmt.Fprintf(stdout, "[tctl] ✓ %s: %s (regenerated by another process)\n", s.tool.Name, msg)
+			l.Release()
+			s.status = stepOK
+			return nil, false
+		}
+	}
+	return l, true
+}
+
+// printProfile lists the steps that ran, slowest first.
+func (p *getPlan) printProfile() {
+	var ran []*planStep
+	for _, s := range p.steps {
+		if s.status == stepOK || s.status == stepFailed {
+			ran = append(ran, s)
+		}
+	}
+	if len(ran) < 2 {
+		return
+	}
+
+	sort.Slice(ran, func(i, j int) bool {
+		return ran[i].duration > ran[j].duration
+	})
+
+	fmt.Fprintln(os.Stderr, "[tctl] slowest tools:")
+	for _, s := range ran {
+		fmt.Fprintf(os.Stderr, "  %7.2fs  %s\n", s.duration.Seconds(), s.tool.Name)
+	}
+}
+
+// skipPending marks every step that hasn't run as skipped.
+func (p *getPlan) skipPending() {
+	for _, s := range p.steps {
+		if s.status == stepPending {
+			s.status = stepSkipped
+		}
 	}
-	if exitCode != 0 {
-		fmt.Fprintf(os.Stderr, "[tctl] ✗ %s failed with code %d\n", t.Name, exitCode)
+}
+
+// succeeded reports whether target resolved and every step it needs ran
+// successfully.
+func (p *getPlan) succeeded(target string) bool {
+	if !p.resolved[target] {
 		return false
 	}
+	seen := make(map[*planStep]bool)
+	var check func(steps []*planStep) bool
+	check = func(steps []*planStep) bool {
+		for _, s := range steps {
+			if seen[s] {
+				continue
+			}
+			seen[s] = true
+			if s.status != stepOK || !check(s.deps) {
+				return false
+			}
+		}
+		return true
+	}
+	return check(p.targets[target])
+}
+
+// runStep runs a single tool and reports whether it succeeded: it exited
+// 0 and, unless opts.noOutputCheck is set, wrote its @output. In parallel
+// mode its output is prefixed with the tool name so interleaved lines stay
+// readable. A non-empty opts.outputDir becomes the tool's working directory
+// and its TCTL_OUTPUT_DIR.
+func runStep(t *tool.Tool, args []string, parallel bool, getOpts getOptions) (runner.RunResult, bool) {
+	var opts runner.ExecOptions
+	if outputDir := getOpts.outputDir; outputDir != "" {
+		opts.Dir = outputDir
+		opts.Env = []string{outputDirEnv + "=" + outputDir}
+	}
+	stdout, stderr := logWriters(parallel, getOpts)
+	opts.Stdout = getOpts.log
+	if parallel {
+		toolStdout := newPrefixWriter(getOpts.log, t.Name)
+		toolStderr := newPrefixWriter(os.Stderr, t.Name)
+		defer toolStdout.Flush()
+		defer toolStderr.Flush()
+		opts.Stdout = toolStdout
+		opts.Stderr = toolStderr
+		fmt.Fprintf(stdout, "[tctl] running: %s\n", t.Name)
+	}
+
+	outputPath := t.OutputPathIn(getOpts.outputDir)
+	before, _ := freshness.ModTime(outputPath)
+
+	// The measure to compare with, or why there is none
+	var measureBefore outputMeasure
+	var measureErr error
+	if getOpts.measureOutput && t.Output != "" {
+		if measureErr = measurable(outputPath); measureErr == nil {
+			measureBefore, measureErr = measureOutput(outputPath)
+		}
+	}
+
+	warnIfDeprecated(stderr, t)
+	res := runner.Execute(t, args, opts)
+	runlog.Append(t.Name, args, res)
+	if res.Error != nil {
+		fmt.Fprintf(stderr, "[tctl] ✗ %s: %v\n", t.Name, res.Error)
+		return res, false
+	}
+	if res.ExitCode != 0 {
+		fmt.Fprintf(stderr, "[tctl] ✗ %s failed with code %d\n", t.Name, res.ExitCode)
+		return res, false
+	}
 
 	if t.Output != "" {
-		fmt.Printf("     → output: %s\n", t.Output)
+		if !getOpts.noOutputCheck {
+			if err := checkOutputWritten(outputPath, before); err != nil {
+				fmt.Fprintf(stderr, "[tctl] ✗ %s exited 0 but %v\n", t.Name, err)
+				return res, false
+			}
+		}
+		if !getOpts.measureOutput {
+			fmt.Fprintf(stdout, "     → output: %s\n", t.Output)
+		} else if measureErr != nil {
+			fmt.Fprintf(stdout, "     → output: %s (%v)\n", t.Output, measureErr)
+		} else if after, err := measureOutput(outputPath); err != nil {
+			fmt.Fprintf(stdout, "     → output: %s (not measured: %v)\n", t.Output, err)
+		} else {
+			fmt.Fprintf(stdout, "     → output: %s (%s)\n", t.Output, describeMeasure(measureBefore, after))
+		}
 	}
 
-	return true
+	return res, true
+}
+
+// checkOutputWritten reports an error if the output at path is missing or
+// was last written no later than before, its modification time from just
+// before the tool ran (zero if it didn't exist).
+func checkOutputWritten(path string, before time.Time) error {
+	after, err := freshness.ModTime(path)
+	if err != nil {
+		return fmt.Errorf("did not write its @output %s", path)
+	}
+	if !after.After(before) {
+		return fmt.Errorf("did not update its @output %s", path)
+	}
+	return nil
+}
+
+// outputMu serializes writes from prefixWriters and lockedWriters so
+// lines from parallel tools and tctl's own messages never interleave
+// mid-line.
+var outputMu sync.Mutex
+
+// lockedWriter writes to w while holding outputMu. Each fmt.Fprintf to it
+// is a single write, so a message is never split by a tool's line.
+type lockedWriter struct {
+	w io.Writer
+}
+
+func (l lockedWriter) Write(b []byte) (int, error) {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	return l.w.Write(b)
+}
+
+// logWriters returns where tctl's messages about a step go: opts.log and
+// stderr. In parallel mode they are serialized with the tools' prefixed
+// output.
+func logWriters(parallel bool, opts getOptions) (stdout, stderr io.Writer) {
+	if parallel {
+		return lockedWriter{opts.log}, lockedWriter{os.Stderr}
+	}
+	return opts.log, os.Stderr
+}
+
+// prefixWriter writes each complete line to w with a "[name] " prefix.
+type prefixWriter struct {
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(w io.Writer, name string) *prefixWriter {
+	return &prefixWriter{w: w, prefix: []byte("[" + name + "] ")}
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		idx := bytes.IndexByte(p.buf, '\n')
+		if idx == -1 {
+			break
+		}
+		p.writeLine(p.buf[:idx+1])
+		p.buf = p.buf[idx+1:]
+	}
+	return len(b), nil
+}
+
+// Flush writes any trailing partial line.
+func (p *prefixWriter) Flush() {
+	if len(p.buf) > 0 {
+		p.writeLine(append(p.buf, '\n'))
+		p.buf = nil
+	}
+}
+
+func (p *prefixWriter) writeLine(line []byte) {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	p.w.Write(p.prefix)
+	p.w.Write(line)
 }
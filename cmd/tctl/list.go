@@ -2,27 +2,51 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/yourname/tctl/internal/config"
 	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
+	"github.com/yourname/tctl/internal/util"
+	"github.com/yourname/tctl/pkg/tool"
 )
 
 func listCmd() *cobra.Command {
 	var sourceName string
+	var groupBy string
+	var tags []string
+	var since string
+	var languages []string
+	var sortBy string
+	var paging pageFlags
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all tools",
 		Long: `List all tools from all registered sources.
 
+Tools are listed by name, or newest first with --since. --sort picks
+the order instead: name, or recent (newest file or sidecar first). Ties
+are broken by name.
+
+With --page or --page-size, lists one page of the tools at a time.
+
 Examples:
   tctl list                    # All tools
-  tctl list --source scripts   # Only from 'scripts' source`,
+  tctl list --source scripts   # Only from 'scripts' source
+  tctl list --tag team:data    # Only tools tagged team:data
+  tctl list --tag experimental --tag team:data  # Tools with both tags
+  tctl list --group-by category
+  tctl list --language python  # Only Python tools
+  tctl list --since 7d         # Tools changed this week, newest first
+  tctl list --sort recent      # All tools, newest first
+  tctl list --page 2           # Tools 21-40`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -36,7 +60,7 @@ Examples:
 				if src == nil {
 					return fmt.Errorf("unknown source: %s", sourceName)
 				}
-				paths = []string{src.Path}
+				paths = []string{src.Dir()}
 			} else {
 				paths = cfg.SourcePaths()
 			}
@@ -52,49 +76,251 @@ Examples:
 				return err
 			}
 
-			tools := registry.All()
+			warnUnknownLanguages(languages)
+
+			var window time.Duration
+			if since != "" {
+				if window, err = util.ParseDuration(since); err != nil {
+					return fmt.Errorf("--since: %w", err)
+				}
+			}
+
+			// Sort by name, or newest first with --since
+			if sortBy == "" {
+				sortBy = "name"
+				if since != "" {
+					sortBy = "recent"
+				}
+			}
+			order, err := newToolOrder(sortBy, "name", "recent")
+			if err != nil {
+				return err
+			}
+
+			var tools []*tool.Tool
+			for _, t := range registry.All() {
+				if !hasAllTags(t, tags) || !hasLanguage(t, languages) {
+					continue
+				}
+				if since != "" && time.Since(order.modTime(t)) > window {
+					continue
+				}
+				tools = append(tools, t)
+			}
 			if len(tools) == 0 {
 				fmt.Println("No tools found.")
 				return nil
 			}
 
-			// Sort by name
 			sort.Slice(tools, func(i, j int) bool {
-				return tools[i].Name < tools[j].Name
+				return order.less(tools[i], tools[j], 0, 0)
 			})
 
+			pages := 0
+			if paging.enabled() {
+				start, end, n, err := paging.bounds(len(tools))
+				if err != nil {
+					return err
+				}
+				tools, pages = tools[start:end], n
+			}
+
 			// Build source name lookup
 			sourceNames := make(map[string]string)
 			for _, src := range cfg.Sources.Sources {
-				sourceNames[src.Path] = src.Name
+				sourceNames[src.Dir()] = src.Name
 			}
 
-			fmt.Println()
-			fmt.Println("Tools:")
-
-			for _, t := range tools {
-				provides := strings.Join(t.Provides, ", ")
-				srcName := sourceNames[filepath.Dir(t.File)]
-				if srcName == "" {
-					srcName = filepath.Base(filepath.Dir(t.File))
-				}
-
-				if provides != "" {
-					fmt.Printf("  %-24s [%s] → %s\n", t.Name, srcName, provides)
-				} else {
-					fmt.Printf("  %-24s [%s]\n", t.Name, srcName)
-				}
-
-				if t.Output != "" {
-					fmt.Printf("  %-24s       %s\n", "", t.Output)
+			switch groupBy {
+			case "":
+				fmt.Println()
+				fmt.Println("Tools:")
+				for _, t := range tools {
+					printListTool(t, registry, sourceNames)
 				}
+			case "category":
+				printToolsByCategory(tools, registry, sourceNames)
+			default:
+				return fmt.Errorf("unknown --group-by value: %s (valid: category)", groupBy)
 			}
 
 			fmt.Println()
+			if paging.enabled() {
+				paging.printPageFooter(pages)
+			}
+			if n := len(registry.Errors); n > 0 {
+				fmt.Fprintf(os.Stderr, "%s %d files failed to scan. Run 'tctl sync' for details.\n", term.Yellow("⚠"), n)
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&sourceName, "source", "s", "", "Filter by source name")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Group tools under headers (category)")
+	cmd.Flags().StringArrayVarP(&tags, "tag", "t", nil, "Only tools with this exact @tag (repeatable)")
+	cmd.Flags().StringArrayVar(&languages, "language", nil, "Only tools in this language (repeatable)")
+	cmd.Flags().StringVar(&since, "since", "", "Only tools whose file changed within this long (e.g. 24h, 7d)")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "Order tools by name or recent (default name; recent with --since)")
+	addPageFlags(cmd, &paging)
 	return cmd
 }
+
+// toolModTime returns when t's file, or its sidecar, was last modified.
+func toolModTime(t *tool.Tool) time.Time {
+	var newest time.Time
+	for _, path := range []string{t.File, scanner.SidecarPath(t.File)} {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest
+}
+
+// toolOrder is a --sort order for list and find: "name", "score" (find's
+// relevance, best first), or "recent" (newest file first, by
+// toolModTime). Ties are broken by name, so output is stable across runs.
+type toolOrder struct {
+	by       string
+	modTimes map[*tool.Tool]time.Time
+}
+
+// newToolOrder returns the order named by, which must be one of allowed.
+func newToolOrder(by string, allowed ...string) (*toolOrder, error) {
+	for _, name := range allowed {
+		if by == name {
+			return &toolOrder{by: by, modTimes: make(map[*tool.Tool]time.Time)}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown --sort value: %s (valid: %s)", by, strings.Join(allowed, ", "))
+}
+
+// less reports whether a sorts before b. scoreA and scoreB are their find
+// scores; they are only compared when sorting by score.
+func (o *toolOrder) less(a, b *tool.Tool, scoreA, scoreB int) bool {
+	switch o.by {
+	case "score":
+		if scoreA != scoreB {
+			return scoreA > scoreB
+		}
+	case "recent":
+		if ta, tb := o.modTime(a), o.modTime(b); !ta.Equal(tb) {
+			return ta.After(tb)
+		}
+	}
+	return a.Name < b.Name
+}
+
+// modTime returns toolModTime(t), statting each tool's files only once.
+func (o *toolOrder) modTime(t *tool.Tool) time.Time {
+	if mt, ok := o.modTimes[t]; ok {
+		return mt
+	}
+	mt := toolModTime(t)
+	o.modTimes[t] = mt
+	return mt
+}
+
+// hasAllTags reports whether t carries every one of tags.
+func hasAllTags(t *tool.Tool, tags []string) bool {
+	for _, tag := range tags {
+		if !t.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasLanguage reports whether t is written in one of langs. Every tool
+// passes when langs is empty.
+func hasLanguage(t *tool.Tool, langs []string) bool {
+	if len(langs) == 0 {
+		return true
+	}
+	for _, lang := range langs {
+		if strings.EqualFold(t.Language, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// warnUnknownLanguages prints a warning for each of langs that no scanner
+// handles, so a typo doesn't look like an empty result.
+func warnUnknownLanguages(langs []string) {
+	var supported []string
+	for _, s := range scanner.AllScanners() {
+		supported = append(supported, s.Language())
+	}
+	sort.Strings(supported)
+
+	for _, lang := range langs {
+		if scanner.GetScannerByLanguage(strings.ToLower(lang)) == nil {
+			fmt.Fprintf(os.Stderr, "%s Unknown language: %s (supported: %s)\n",
+				term.Yellow("⚠"), lang, strings.Join(supported, ", "))
+		}
+	}
+}
+
+// printToolsByCategory prints tools under sorted @category headers,
+// with uncategorized tools last.
+func printToolsByCategory(tools []*tool.Tool, registry *tool.Registry, sourceNames map[string]string) {
+	groups := make(map[string][]*tool.Tool)
+	var categories []string
+	for _, t := range tools {
+		if t.Category != "" && groups[t.Category] == nil {
+			categories = append(categories, t.Category)
+		}
+		groups[t.Category] = append(groups[t.Category], t)
+	}
+	sort.Strings(categories)
+	if len(groups[""]) > 0 {
+		categories = append(categories, "")
+	}
+
+	for _, category := range categories {
+		header := category
+		if header == "" {
+			header = "(uncategorized)"
+		}
+		fmt.Println()
+		fmt.Printf("%s:\n", header)
+		for _, t := range groups[category] {
+			printListTool(t, registry, sourceNames)
+		}
+	}
+}
+
+// printListTool prints one tool's entry in 'tctl list'.
+func printListTool(t *tool.Tool, registry *tool.Registry, sourceNames map[string]string) {
+	provides := strings.Join(t.Provides, ", ")
+	srcName := sourceNameFor(t.File, sourceNames)
+
+	deprecated := ""
+	if t.Deprecated {
+		deprecated = " " + term.Yellow("(deprecated)")
+	}
+
+	if provides != "" {
+		fmt.Printf("  %-24s [%s] → %s%s\n", t.Name, srcName, provides, deprecated)
+	} else {
+		fmt.Printf("  %-24s [%s]%s\n", t.Name, srcName, deprecated)
+	}
+
+	if t.Output != "" {
+		fmt.Printf("  %-24s       %s\n", "", t.Output)
+	}
+
+	// Name collision: show which definitions this one overrides
+	for _, s := range registry.Shadowed[t.Name] {
+		fmt.Printf("  %-24s       %s\n", "", term.Dim(fmt.Sprintf("overrides [%s] %s", sourceNameFor(s.File, sourceNames), s.File)))
+	}
+}
+
+// sourceNameFor returns the name of the source containing file, falling
+// back to the name of its directory.
+func sourceNameFor(file string, sourceNames map[string]string) string {
+	if name := sourceNames[filepath.Dir(file)]; name != "" {
+		return name
+	}
+	return filepath.Base(filepath.Dir(file))
+}
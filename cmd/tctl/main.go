@@ -2,19 +2,34 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
 
-	// Import runners and scanners to register them
+	// Import runners to register them
 	_ "github.com/yourname/tctl/internal/runner"
-	_ "github.com/yourname/tctl/internal/scanner"
 )
 
 const version = "0.2.0"
 
+// enableVerbose logs scanner decisions to stderr.
+func enableVerbose() {
+	scanner.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		// Drop timestamps: the order of the records is what matters
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+}
+
 func main() {
 	// Ensure config directory exists
 	config.EnsureConfigDir()
@@ -33,10 +48,28 @@ Quick start:
 		Version: version,
 	}
 
+	var noColor, verbose bool
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Log why each file was scanned or skipped to stderr")
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if noColor {
+			term.Disable()
+		}
+		if verbose {
+			enableVerbose()
+		}
+		if cfg, err := config.Load(); err == nil {
+			for _, warning := range cfg.Warnings {
+				fmt.Fprintf(os.Stderr, "[tctl] %s %s\n", term.Yellow("⚠"), warning)
+			}
+		}
+	}
+
 	// Source management
 	rootCmd.AddCommand(addCmd())
 	rootCmd.AddCommand(removeCmd())
 	rootCmd.AddCommand(sourcesCmd())
+	rootCmd.AddCommand(moveCmd())
 
 	// Tool discovery
 	rootCmd.AddCommand(listCmd())
@@ -44,16 +77,30 @@ Quick start:
 	rootCmd.AddCommand(findCmd())
 	rootCmd.AddCommand(whereCmd())
 	rootCmd.AddCommand(showCmd())
+	rootCmd.AddCommand(consumersCmd())
+	rootCmd.AddCommand(intentsCmd())
+	rootCmd.AddCommand(categoriesCmd())
+	rootCmd.AddCommand(tagsCmd())
 
 	// Tool execution
 	rootCmd.AddCommand(runCmd())
 	rootCmd.AddCommand(getCmd())
+	rootCmd.AddCommand(logsCmd())
+	rootCmd.AddCommand(envCmd())
+	rootCmd.AddCommand(installCmd())
 
 	// Maintenance
 	rootCmd.AddCommand(newCmd())
 	rootCmd.AddCommand(syncCmd())
+	rootCmd.AddCommand(diffCmd())
 	rootCmd.AddCommand(statusCmd())
 	rootCmd.AddCommand(lintCmd())
+	rootCmd.AddCommand(validateCmd())
+	rootCmd.AddCommand(configCmd())
+	rootCmd.AddCommand(cleanCmd())
+	rootCmd.AddCommand(exportCmd())
+	rootCmd.AddCommand(catalogCmd())
+	rootCmd.AddCommand(importCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
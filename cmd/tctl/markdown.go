@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// writeToolMarkdown writes t as a Markdown document whose title is a
+// heading of the given level (1 for "#"); its sections are one level
+// deeper. registry is used to name the providers of required data.
+func writeToolMarkdown(w io.Writer, t *tool.Tool, registry *tool.Registry, level int) {
+	title := strings.Repeat("#", level)
+	section := title + "#"
+
+	fmt.Fprintf(w, "%s %s\n\n", title, t.Name)
+
+	if t.Description != "" {
+		fmt.Fprintf(w, "%s\n\n", t.Description)
+	}
+	if t.Deprecated {
+		fmt.Fprintf(w, "> **Deprecated:** %s\n\n", deprecationNote(t))
+	}
+
+	fmt.Fprintln(w, "| Field | Value |")
+	fmt.Fprintln(w, "|-------|-------|")
+	fmt.Fprintf(w, "| File | %s |\n", mdCode(t.File))
+	fmt.Fprintf(w, "| Language | %s |\n", mdCell(t.Language))
+	fields := []struct{ name, value string }{
+		{"Version", mdCell(t.Version)},
+		{"Output", mdCode(t.Output)},
+		{"Output format", mdCell(t.OutputFormat)},
+		{"Freshness", mdCell(t.Freshness)},
+		{"Category", mdCell(t.Category)},
+		{"Tags", mdCell(strings.Join(t.Tags, ", "))},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			fmt.Fprintf(w, "| %s | %s |\n", f.name, f.value)
+		}
+	}
+	fmt.Fprintln(w)
+
+	if len(t.Provides) > 0 || len(t.Requires) > 0 {
+		fmt.Fprintf(w, "%s Data\n\n", section)
+		fmt.Fprintln(w, "| Direction | Data | Tool |")
+		fmt.Fprintln(w, "|-----------|------|------|")
+		for _, p := range t.Provides {
+			fmt.Fprintf(w, "| Provides | %s | %s |\n", mdCode(p), mdCode(t.Name))
+		}
+		for _, r := range t.Requires {
+			data := r
+			if c := t.Constraint(r); c != nil {
+				data = c.String()
+			}
+			provider := "(none)"
+			if p, err := registry.FindByProvides(r); err != nil {
+				provider = "(ambiguous)"
+			} else if p != nil {
+				provider = mdCode(p.Name)
+			}
+			fmt.Fprintf(w, "| Requires | %s | %s |\n", mdCode(data), provider)
+		}
+		fmt.Fprintln(w)
+	}
+
+	var needs []string
+	for _, r := range t.Runtime {
+		needs = append(needs, "Runtime: "+mdCode(r.String()))
+	}
+	for _, c := range t.RequiresCmd {
+		needs = append(needs, "Command: "+mdCode(c))
+	}
+	for _, v := range t.RequiresEnv {
+		needs = append(needs, "Environment variable: "+mdCode(v))
+	}
+	for _, p := range t.PipRequires {
+		needs = append(needs, "Python package: "+mdCode(p))
+	}
+	writeMarkdownList(w, section+" Requirements", needs)
+
+	writeMarkdownList(w, section+" Capabilities", t.Capabilities)
+	writeMarkdownList(w, section+" Boundaries", t.Boundaries)
+
+	if len(t.Interface) > 0 {
+		fmt.Fprintf(w, "%s Interface\n\n", section)
+		fmt.Fprintln(w, "| Argument | Type | Required | Default | Description |")
+		fmt.Fprintln(w, "|----------|------|----------|---------|-------------|")
+		for _, arg := range t.InterfaceArgs() {
+			required := "no"
+			if arg.Required {
+				required = "yes"
+			}
+			desc := arg.Description
+			if len(arg.Choices) > 0 {
+				desc = strings.TrimSpace(desc + " One of: " + strings.Join(arg.Choices, ", ") + ".")
+			}
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n",
+				mdCode(arg.Name), mdCell(arg.Type), required, mdCode(arg.Default), mdCell(desc))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(t.Examples) > 0 {
+		fmt.Fprintf(w, "%s Examples\n\n", section)
+
+		// Unlabeled examples share one block; each labeled one gets its
+		// own heading
+		var unlabeled []string
+		for _, ex := range t.Examples {
+			if ex.Label == "" {
+				unlabeled = append(unlabeled, ex.Command)
+			}
+		}
+		writeMarkdownCode(w, unlabeled)
+		for _, ex := range t.Examples {
+			if ex.Label != "" {
+				fmt.Fprintf(w, "%s# %s\n\n", section, ex.Label)
+				writeMarkdownCode(w, []string{ex.Command})
+			}
+		}
+	}
+
+	var related []string
+	for _, name := range t.Related {
+		related = append(related, mdCode(name))
+	}
+	writeMarkdownList(w, section+" Related tools", related)
+}
+
+// writeMarkdownList writes a heading followed by a bullet list, or
+// nothing if items is empty.
+func writeMarkdownList(w io.Writer, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s\n\n", heading)
+	for _, item := range items {
+		fmt.Fprintf(w, "- %s\n", item)
+	}
+	fmt.Fprintln(w)
+}
+
+// writeMarkdownCode writes lines as a shell code block, or nothing if
+// there are none.
+func writeMarkdownCode(w io.Writer, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "```sh")
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w, "```")
+	fmt.Fprintln(w)
+}
+
+// mdCode formats s as inline code, or returns "" for an empty string.
+func mdCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + mdCell(s) + "`"
+}
+
+// mdCell makes s safe to use inside a table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
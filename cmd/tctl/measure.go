@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourname/tctl/internal/freshness"
+)
+
+// lineFormats maps the extensions of line-based text outputs to what
+// --measure-output calls their lines. A CSV or TSV header isn't a row.
+var lineFormats = map[string]string{
+	".csv":    "rows",
+	".tsv":    "rows",
+	".jsonl":  "lines",
+	".ndjson": "lines",
+	".txt":    "lines",
+	".log":    "lines",
+}
+
+// outputMeasure is what --measure-output reports about an output file:
+// its size and, for line-based text formats, how many rows it has.
+type outputMeasure struct {
+	exists bool
+	size   int64
+	rows   int
+	unit   string // "rows" or "lines"; empty if rows weren't counted
+}
+
+// measurable returns why --measure-output can't report on the output at
+// path, or nil if it can: only single files are measured, not
+// directories or glob patterns.
+func measurable(path string) error {
+	if freshness.IsGlob(path) {
+		return fmt.Errorf("not measured: %s is a glob pattern", path)
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return fmt.Errorf("not measured: %s is a directory", path)
+	}
+	return nil
+}
+
+// measureOutput measures the file at path. A missing file measures as
+// not existing.
+func measureOutput(path string) (outputMeasure, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return outputMeasure{}, nil
+	}
+	if err != nil {
+		return outputMeasure{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return outputMeasure{}, err
+	}
+	m := outputMeasure{exists: true, size: info.Size()}
+
+	unit, ok := lineFormats[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return m, nil
+	}
+	lines, err := countLines(f)
+	if err != nil {
+		return outputMeasure{}, err
+	}
+	if unit == "rows" && lines > 0 {
+		lines-- // the header
+	}
+	m.rows, m.unit = lines, unit
+	return m, nil
+}
+
+// countLines counts the lines in r, including a last line without a
+// trailing newline.
+func countLines(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	buf := make([]byte, 32*1024)
+	lines := 0
+	var last byte
+	for {
+		n, err := br.Read(buf)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if last != 0 && last != '\n' {
+		lines++
+	}
+	return lines, nil
+}
+
+// describeMeasure describes after compared with before, the measure of
+// the same file before the run, e.g. "1,240 rows, +37; 48.2 KB, +1.4 KB".
+func describeMeasure(before, after outputMeasure) string {
+	if !after.exists {
+		return "no output file"
+	}
+
+	var parts []string
+	if after.unit != "" {
+		part := formatCount(int64(after.rows)) + " " + after.unit
+		if before.exists && before.unit != "" {
+			part += ", " + signed(int64(after.rows-before.rows), formatCount)
+		}
+		parts = append(parts, part)
+	}
+	part := formatSize(after.size)
+	if before.exists {
+		part += ", " + signed(after.size-before.size, formatSize)
+	}
+	parts = append(parts, part)
+
+	desc := strings.Join(parts, "; ")
+	if !before.exists {
+		desc += "; new"
+	}
+	return desc
+}
+
+// signed formats a change with an explicit sign: +37, -1.2 KB, +0.
+func signed(n int64, format func(int64) string) string {
+	if n < 0 {
+		return "-" + format(-n)
+	}
+	return "+" + format(n)
+}
+
+// formatCount formats n with thousands separators: 1,240.
+func formatCount(n int64) string {
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	s := fmt.Sprint(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// formatSize formats a byte count for people: 512 B, 48.2 KB, 3.1 MB.
+func formatSize(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	size, unit := float64(n)/1024, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if size < 1024 {
+			break
+		}
+		size, unit = size/1024, next
+	}
+	return fmt.Sprintf("%.1f %s", size, unit)
+}
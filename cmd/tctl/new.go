@@ -7,10 +7,14 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/scanner"
 )
 
 func newCmd() *cobra.Command {
 	var outputDir string
+	var lang string
 
 	cmd := &cobra.Command{
 		Use:   "new <tool-name>",
@@ -18,92 +22,104 @@ func newCmd() *cobra.Command {
 		Long: `Create a new tool file with a template docstring.
 By default, creates in current directory.
 
+The language defaults to the default_language of the source the tool is
+created in (from its .tctl.yaml), then to the default_language setting
+(python).
+Available languages: ` + strings.Join(templateLanguages(), ", ") + `
+
 Examples:
   tctl new my-scraper              # Creates ./my_scraper.py
-  tctl new my-scraper -o ~/tools   # Creates ~/tools/my_scraper.py`,
+  tctl new my-scraper -o ~/tools   # Creates ~/tools/my_scraper.py
+  tctl new my-scraper --lang typescript  # Creates ./my-scraper.ts`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			toolName := args[0]
-
-			// Determine output directory
-			dir := outputDir
-			if dir == "" {
-				var err error
-				dir, err = os.Getwd()
+			if lang == "" {
+				cfg, err := config.Load()
 				if err != nil {
 					return err
 				}
+				lang = defaultLanguage(cfg, outputDir)
 			}
-
-			// Create file
-			fileName := strings.ReplaceAll(toolName, "-", "_") + ".py"
-			filePath := filepath.Join(dir, fileName)
-
-			if _, err := os.Stat(filePath); err == nil {
-				return fmt.Errorf("file already exists: %s", filePath)
-			}
-
-			content := fmt.Sprintf(pythonToolTemplate, fileName, toolName, toolName, toolName)
-			if err := os.WriteFile(filePath, []byte(content), 0755); err != nil {
-				return err
-			}
-
-			fmt.Printf("✓ Created: %s\n", filePath)
-			fmt.Println()
-			fmt.Println("Next steps:")
-			fmt.Printf("  1. Edit %s - fill in @tags\n", filePath)
-			fmt.Printf("  2. Register the directory: tctl add %s\n", dir)
-			fmt.Println("  3. Validate: tctl doctor")
-			fmt.Printf("  4. Run: tctl run %s --help\n", toolName)
-
-			return nil
+			return createTool(args[0], lang, outputDir, "", nil)
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputDir, "output", "o", "", "Output directory")
+	cmd.Flags().StringVarP(&lang, "lang", "l", "", "Template language (default from settings)")
 	return cmd
 }
 
-const pythonToolTemplate = `#!/usr/bin/env python3
-"""
-%s
-
-TODO: One-line description of what this tool does.
-
-@tool %s
-@version 0.1.0
-@provides TODO-data-name
-@requires
-@output data/TODO-output.csv
-@freshness daily
-
-@capability TODO: Describe what this tool does
-@capability TODO: Add more capabilities
-
-@boundary TODO: Does NOT do X (use other-tool for that)
-
-@keywords TODO, add, search, terms
+// defaultLanguage returns the template language for a new tool in dir
+// (the current directory if empty): the default_language in the
+// .tctl.yaml of the source containing dir, otherwise the default_language
+// setting.
+func defaultLanguage(cfg *config.Global, dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return cfg.Settings.DefaultLanguage
+	}
+	for _, src := range cfg.Sources.Sources {
+		rel, err := filepath.Rel(src.Dir(), abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if source, err := scanner.LoadSourceConfig(src.Dir()); err == nil && source.DefaultLanguage != "" {
+			return source.DefaultLanguage
+		}
+	}
+	return cfg.Settings.DefaultLanguage
+}
 
-@interface
-  --out: file, required - Output file path
+// createTool writes a new tool file from the template for lang into
+// outputDir (the current directory if empty) and prints the next steps.
+// An empty description or keyword list leaves the template's placeholders.
+func createTool(toolName, lang, outputDir, description string, keywords []string) error {
+	if lang == "" {
+		lang = "python"
+	}
+	tmpl, err := getToolTemplate(lang)
+	if err != nil {
+		return err
+	}
 
-@example tctl run %s --out data/output.csv
-"""
+	// Determine output directory
+	dir := outputDir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
 
-import argparse
-import sys
+	// Create file
+	fileName := tmpl.fileName(toolName)
+	filePath := filepath.Join(dir, fileName)
 
+	if _, err := os.Stat(filePath); err == nil {
+		return fmt.Errorf("file already exists: %s", filePath)
+	}
 
-def main():
-    ap = argparse.ArgumentParser(description="TODO: Description")
-    ap.add_argument("--out", required=True, help="Output file path")
-    args = ap.parse_args()
+	if description == "" {
+		description = "TODO: One-line description of what this tool does."
+	}
+	keywordLine := "TODO, add, search, terms"
+	if len(keywords) > 0 {
+		keywordLine = strings.Join(keywords, ", ")
+	}
 
-    # TODO: Implement tool logic
-    print(f"TODO: Implement %s")
-    print(f"Output would go to: {args.out}")
+	content := fmt.Sprintf(tmpl.body, fileName, toolName, description, keywordLine)
+	if err := os.WriteFile(filePath, []byte(content), 0755); err != nil {
+		return err
+	}
 
+	fmt.Printf("✓ Created: %s\n", filePath)
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. Edit %s - fill in @tags\n", filePath)
+	fmt.Printf("  2. Register the directory: tctl add %s\n", dir)
+	fmt.Println("  3. Validate: tctl doctor")
+	fmt.Printf("  4. Run: tctl run %s --help\n", toolName)
 
-if __name__ == "__main__":
-    main()
-`
+	return nil
+}
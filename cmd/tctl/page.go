@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultPageSize is the page size when only --page is given.
+const defaultPageSize = 20
+
+// pageFlags holds the --page and --page-size options of list, find, and
+// where. Results are paginated only if either is given.
+type pageFlags struct {
+	page int
+	size int
+}
+
+// addPageFlags registers --page and --page-size on cmd.
+func addPageFlags(cmd *cobra.Command, p *pageFlags) {
+	cmd.Flags().IntVar(&p.page, "page", 0, "Show only this page of results (starting at 1)")
+	cmd.Flags().IntVar(&p.size, "page-size", 0, fmt.Sprintf("Results per page (default %d with --page)", defaultPageSize))
+}
+
+// enabled reports whether pagination was asked for.
+func (p pageFlags) enabled() bool {
+	return p.page != 0 || p.size != 0
+}
+
+// bounds returns the range [start, end) of n results that the requested
+// page covers and the number of pages.
+func (p pageFlags) bounds(n int) (start, end, pages int, err error) {
+	page, size := p.page, p.size
+	if page == 0 {
+		page = 1
+	}
+	if size == 0 {
+		size = defaultPageSize
+	}
+	if page < 1 {
+		return 0, 0, 0, fmt.Errorf("--page must be at least 1")
+	}
+	if size < 1 {
+		return 0, 0, 0, fmt.Errorf("--page-size must be at least 1")
+	}
+
+	pages = max(1, (n+size-1)/size)
+	if page > pages {
+		return 0, 0, 0, fmt.Errorf("--page %d is past the last page (%d)", page, pages)
+	}
+	start = (page - 1) * size
+	return start, min(start+size, n), pages, nil
+}
+
+// printPageFooter prints which page of how many was shown, e.g.
+// "page 2/7 (next: --page 3)".
+func (p pageFlags) printPageFooter(pages int) {
+	page := max(p.page, 1)
+	if page < pages {
+		fmt.Printf("page %d/%d (next: --page %d)\n", page, pages, page+1)
+	} else {
+		fmt.Printf("page %d/%d\n", page, pages)
+	}
+}
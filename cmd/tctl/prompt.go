@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/yourname/tctl/internal/term"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// promptMissingArgs asks on stdin for each required @interface argument
+// that args doesn't give, and returns args with the answers appended.
+// Answers are checked against the argument's type and choices, and asked
+// again until they pass.
+func promptMissingArgs(t *tool.Tool, args []string) ([]string, error) {
+	missing := missingRequiredArgs(t, args)
+	if len(missing) == 0 {
+		return args, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "[tctl] %s needs %d more argument(s):\n", t.Name, len(missing))
+	in := bufio.NewReader(os.Stdin)
+	for _, arg := range missing {
+		for {
+			fmt.Fprint(os.Stderr, promptLabel(arg))
+			line, err := in.ReadString('\n')
+			value := strings.TrimSpace(line)
+			if err == io.EOF && value == "" {
+				fmt.Fprintln(os.Stderr)
+				return nil, fmt.Errorf("no value given for %s", arg.Name)
+			}
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			if value == "" {
+				continue
+			}
+			if err := arg.CheckValue(value); err != nil {
+				fmt.Fprintf(os.Stderr, "  %s %v\n", term.Red("✗"), err)
+				continue
+			}
+
+			switch {
+			case arg.Positional:
+				args = append(args, value)
+			case arg.Type == "bool":
+				// A bool flag is given by name alone
+				if b, _ := strconv.ParseBool(value); b {
+					args = append(args, arg.Name)
+				}
+			default:
+				args = append(args, arg.Name, value)
+			}
+			break
+		}
+	}
+	return args, nil
+}
+
+// promptLabel returns the prompt for arg, e.g.
+// "  --mode (choice: full|fast) - Run mode: ".
+func promptLabel(arg tool.Arg) string {
+	label := "  " + arg.Name + " (" + arg.Type
+	if len(arg.Choices) > 0 {
+		label += ": " + strings.Join(arg.Choices, "|")
+	}
+	label += ")"
+	if arg.Description != "" {
+		label += " " + term.Dim("- "+arg.Description)
+	}
+	return label + ": "
+}
+
+// missingRequiredArgs returns the required @interface arguments of t that
+// args doesn't give, in InterfaceArgs order. A flag counts as given if it
+// appears, with or without "=value"; a positional argument counts as given
+// if args has at least as many positional values as its position.
+func missingRequiredArgs(t *tool.Tool, args []string) []tool.Arg {
+	given := make(map[string]bool)
+	positionals := 0
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			positionals++
+			continue
+		}
+		name, _, hasValue := strings.Cut(arg, "=")
+		given[name] = true
+		// The next argument is this flag's value, unless it's a bool
+		if def, ok := t.Interface[name]; ok && !hasValue && def.Type != "bool" {
+			i++
+		}
+	}
+
+	var missing []tool.Arg
+	for _, arg := range t.InterfaceArgs() {
+		if !arg.Required {
+			continue
+		}
+		if arg.Positional && arg.Position > positionals || !arg.Positional && !given[arg.Name] {
+			missing = append(missing, arg)
+		}
+	}
+	return missing
+}
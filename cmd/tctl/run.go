@@ -1,64 +1,685 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/freshness"
+	"github.com/yourname/tctl/internal/runlog"
 	"github.com/yourname/tctl/internal/runner"
 	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/term"
+	"github.com/yourname/tctl/internal/util"
+	"github.com/yourname/tctl/pkg/tool"
 )
 
+// runOptions are tctl's own options for 'tctl run'. Flag parsing is
+// disabled so tool flags pass through untouched; these must therefore
+// come before the tool name.
+type runOptions struct {
+	explain bool   // print resolved metadata before running
+	dryRun  bool   // stop before running the tool
+	capture string // also write the tool's stdout and stderr to this file
+	profile bool   // report how long the tool took
+	trace   bool   // log each step of the tool's process lifecycle
+	input   string // file connected to the tool's stdin
+	noStdin bool   // connect the tool's stdin to the null device
+
+	// checkOutput fails the run if the tool exits 0 without writing its
+	// @output.
+	checkOutput bool
+
+	// timeout kills the tool, and any processes it started, once it has
+	// run this long. Zero means no limit.
+	timeout time.Duration
+
+	// cleanEnv starts the tool with only PATH and the --env variables
+	// instead of tctl's whole environment.
+	cleanEnv bool
+
+	// printOutputPath prints only the absolute path of the tool's
+	// @output on stdout, after a successful run.
+	printOutputPath bool
+
+	// measureOutput reports the size and rows of the tool's @output
+	// after a successful run, and how they changed.
+	measureOutput bool
+
+	// output, from --output, names an artifact whose provider is run in
+	// place of a named tool.
+	output string
+
+	// interactive prompts for required @interface arguments that aren't
+	// given, if stdin is a terminal.
+	interactive bool
+
+	// argsFile holds tool arguments, placed before any given on the
+	// command line.
+	argsFile string
+
+	// env holds KEY=VALUE pairs set on top of the inherited environment.
+	env []string
+}
+
 func runCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "run <tool-name> [args...]",
+		Use:   "run [options] <tool-name | --output <artifact>> [args...]",
 		Short: "Run a tool directly with arguments",
 		Long: `Execute a tool by name, passing any additional arguments.
 
+The tool can also be given as a path to a tool file, which is scanned and
+run directly without registering its directory. Handy for testing a tool
+before 'tctl add'. With - as the tool name, the tool's source is read from
+stdin instead, and the tool gets an empty stdin unless --input is given.
+
+With --output <artifact> in place of the tool name, runs the one tool that
+@provides the artifact. Unlike 'tctl get', it runs unconditionally: it
+ignores freshness and doesn't run dependencies first. Arguments after
+the artifact go to the tool.
+
+Options (must come before the tool name):
+  --output <artifact> Run the tool that provides the artifact (instead of a name)
+  --explain           Show how the tool was parsed and will be executed
+  --dry-run           Don't run the tool (combine with --explain)
+  --capture <file>    Also write the tool's output to a file
+  --input <file>      Feed a file to the tool's stdin
+  --no-stdin          Give the tool an empty stdin
+  --profile           Report how long the tool took
+  --trace             Log when the tool's process is set up, starts, and exits
+  -i, --interactive   Prompt for required arguments that weren't given
+  --verbose           Log why each file was scanned or skipped
+  --args-file <file>  Read tool arguments from a file
+  --env KEY=VALUE     Set an environment variable for the tool (repeatable)
+  --clean-env         Don't inherit tctl's environment, only PATH and --env
+  --check-output      Fail if the tool exits 0 without writing its @output
+  --timeout <dur>     Kill the tool if it runs longer than this (30s, 5m, ...)
+  --print-output-path Print only the tool's absolute @output path on stdout
+  --measure-output    Report the @output's size and row count, and the change
+
+Everything after the tool name is passed to the tool untouched, even a
+-- or arguments that look like tctl options, so a tool's own --trace or
+--env reaches the tool. A -- before the tool name ends tctl's options:
+the argument after it is the tool name.
+
+An args file holds shell-style arguments, one or more per line; quote
+arguments that contain spaces. Blank lines and lines starting with #
+are skipped. Its arguments come before any given on the command line.
+
+--trace prints timestamped steps to stderr as tctl starts the tool: the
+interpreter it resolved, the working directory, the environment, the
+process ID, and the exit status. Use it to see where a hanging tool is
+stuck.
+
+With --interactive, tctl asks for each required @interface argument
+missing from the command line, showing its type and description, and
+checks each answer against the argument's type and choices before
+running the tool. It doesn't prompt when stdin isn't a terminal.
+
+The tool reads from tctl's stdin unless --input or --no-stdin is given.
+
+The tool inherits tctl's environment. Variables set with --env take
+precedence over inherited ones, and a later --env wins over an earlier
+one for the same variable.
+
+With --clean-env, the tool gets only PATH and the variables given with
+--env, so tokens and settings in your shell don't leak into tools you
+don't fully trust. Pass anything the tool does need (HOME, for example)
+with --env. This is hygiene, not a sandbox: the tool still runs as you,
+with your files and network.
+
+With --print-output-path, tctl prints the absolute path of the tool's
+@output on stdout after it exits 0, and nothing else: tctl's messages
+and the tool's own output go to stderr. Combine it with --output to
+regenerate data and capture where it is.
+
+With --measure-output, tctl reports the size of the tool's @output file
+after it exits 0 and, for CSV, TSV, and other line-based text files, its
+row or line count, each with the change since before the run. Only
+single files are measured, not directories or glob patterns.
+
+With --timeout, a tool still running after the given duration is killed
+and tctl exits with status 124, like timeout(1), reporting how long it
+ran. On Unix the tool runs in its own process group and the whole group
+is killed, so subprocesses it started don't linger; on Windows its
+process tree is killed. Because the group isn't the terminal's
+foreground group, a tool run with --timeout can't read from the
+terminal, so if stdin is a terminal the tool gets an empty stdin unless
+--input is given. Piped stdin still reaches the tool.
+
 Examples:
   tctl run fetch-prices --symbols AAPL,GOOGL
-  tctl run scrape-gpu --help`,
+  tctl run scrape-gpu --help
+  tctl run ./tools/new_tool.py --out data/x.csv
+  curl -s https://example.com/tool.py | tctl run - --out data/x.csv
+  tctl run --explain --dry-run fetch-prices
+  tctl run --capture run.log fetch-prices --symbols AAPL
+  tctl run --measure-output fetch-prices --symbols AAPL
+  tctl run --trace fetch-prices --symbols AAPL
+  tctl run --timeout 30s scrape-gpu
+  tctl run --dry-run --explain -- fetch-prices --symbols AAPL
+  tctl run --input events.json parse-events
+  tctl run --output prices --symbols AAPL
+  f=$(tctl run --print-output-path --output prices)
+  tctl run -i fetch-prices
+  tctl run --args-file call.txt fetch-prices
+  tctl run --env TOKEN=abc --env DEBUG=1 fetch-prices --symbols AAPL
+  tctl run --clean-env --env HOME=/tmp shared-tool`,
 		Args:               cobra.MinimumNArgs(1),
 		DisableFlagParsing: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load()
+			if args[0] == "-h" || args[0] == "--help" {
+				return cmd.Help()
+			}
+
+			opts, toolName, toolArgs, err := parseRunArgs(args)
 			if err != nil {
 				return err
 			}
 
-			paths := cfg.SourcePaths()
-			if len(paths) == 0 {
-				fmt.Println("No sources registered.")
-				fmt.Println("Register a directory with: tctl add <path>")
-				return nil
+			// os.Exit skips deferred calls, so exits go through exit
+			cleanup := func() {}
+			exit := func(code int) {
+				cleanup()
+				os.Exit(code)
 			}
 
-			toolName := args[0]
-			toolArgs := args[1:]
+			var registry *tool.Registry
+			if toolName == "-" {
+				registry, cleanup, err = scanStdinTool()
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+				toolName = registry.All()[0].Name
+				if opts.input == "" {
+					opts.noStdin = true // stdin held the tool
+				}
+			} else if isToolPath(toolName) {
+				registry, err = scanToolFile(toolName)
+				if err != nil {
+					return err
+				}
+				toolName = registry.All()[0].Name
+			} else {
+				cfg, err := config.Load()
+				if err != nil {
+					return err
+				}
 
-			registry, err := scanner.ScanDirectories(paths)
-			if err != nil {
-				return err
+				paths := cfg.SourcePaths()
+				if len(paths) == 0 {
+					fmt.Println("No sources registered.")
+					fmt.Println("Register a directory with: tctl add <path>")
+					return nil
+				}
+
+				registry, err = scanner.ScanDirectories(paths)
+				if err != nil {
+					return err
+				}
+			}
+
+			if opts.output != "" {
+				provider, err := registry.FindByProvides(opts.output)
+				if err != nil {
+					return err
+				}
+				if provider == nil {
+					fmt.Fprintf(os.Stderr, "[tctl] ✗ No tool provides '%s'\n", opts.output)
+					if hint := didYouMean(opts.output, artifactNames(registry)); hint != "" {
+						fmt.Fprintln(os.Stderr, hint)
+					}
+					os.Exit(1)
+				}
+				toolName = provider.Name
 			}
 
 			tool := registry.Get(toolName)
 			if tool == nil {
 				fmt.Fprintf(os.Stderr, "[tctl] ✗ Unknown tool: %s\n", toolName)
+				if hint := didYouMean(toolName, toolNames(registry)); hint != "" {
+					fmt.Fprintln(os.Stderr, hint)
+				}
 				fmt.Fprintln(os.Stderr, "Run 'tctl list' to see available tools.")
 				os.Exit(1)
 			}
 
-			fmt.Printf("[tctl] running: %s\n", toolName)
+			if opts.interactive {
+				if term.IsTerminal(os.Stdin) {
+					toolArgs, err = promptMissingArgs(tool, toolArgs)
+					if err != nil {
+						return err
+					}
+				} else {
+					fmt.Fprintln(os.Stderr, "[tctl] stdin is not a terminal; not prompting for arguments")
+				}
+			}
 
-			exitCode, err := runner.Run(tool, toolArgs)
-			if err != nil {
-				return err
+			if opts.explain {
+				if err := printRunExplanation(tool, registry, toolArgs, opts); err != nil {
+					return err
+				}
+			}
+			if opts.dryRun {
+				return nil
+			}
+
+			// tctl's messages and the tool's stdout; stdout is kept for the
+			// path with --print-output-path
+			var log io.Writer = os.Stdout
+			if opts.printOutputPath {
+				if tool.Output == "" {
+					return fmt.Errorf("--print-output-path: %s has no @output", tool.Name)
+				}
+				log = os.Stderr
+			}
+
+			execOpts := runner.ExecOptions{Env: opts.env, CleanEnv: opts.cleanEnv, Timeout: opts.timeout, Stdout: log}
+			if opts.timeout > 0 {
+				// The tool's process group doesn't get the terminal's
+				// Ctrl-C, so pass it on by killing the group
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+				defer stop()
+				execOpts.Context = ctx
+			}
+			if opts.input != "" || opts.noStdin {
+				path := opts.input
+				if opts.noStdin {
+					path = os.DevNull
+				}
+				f, err := os.Open(path)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				execOpts.Stdin = f
+			}
+			if opts.capture != "" {
+				f, err := os.Create(opts.capture)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				execOpts.Stdout = io.MultiWriter(log, f)
+				execOpts.Stderr = io.MultiWriter(os.Stderr, f)
+			}
+			if opts.trace {
+				execOpts.Trace = printTrace
 			}
 
-			os.Exit(exitCode)
+			var outputBefore time.Time
+			if opts.checkOutput {
+				outputBefore, _ = freshness.ModTime(tool.OutputPath())
+			}
+			var measureBefore outputMeasure
+			if opts.measureOutput {
+				if tool.Output == "" {
+					return fmt.Errorf("--measure-output: %s has no @output", tool.Name)
+				}
+				if err := measurable(tool.OutputPath()); err != nil {
+					return fmt.Errorf("--measure-output: %w", err)
+				}
+				if measureBefore, err = measureOutput(tool.OutputPath()); err != nil {
+					return err
+				}
+			}
+
+			warnIfDeprecated(os.Stderr, tool)
+			fmt.Fprintf(log, "[tctl] running: %s\n", toolName)
+
+			res := runner.Execute(tool, toolArgs, execOpts)
+			runlog.Append(tool.Name, toolArgs, res)
+			var timeout *runner.TimeoutError
+			if errors.As(res.Error, &timeout) {
+				fmt.Fprintf(os.Stderr, "[tctl] ✗ %v\n", timeout)
+				exit(124)
+			}
+			if res.Error != nil {
+				return res.Error
+			}
+			if opts.checkOutput && res.ExitCode == 0 && tool.Output != "" {
+				if err := checkOutputWritten(tool.OutputPath(), outputBefore); err != nil {
+					fmt.Fprintf(os.Stderr, "[tctl] ✗ %s exited 0 but %v\n", tool.Name, err)
+					exit(1)
+				}
+			}
+
+			if opts.capture != "" {
+				fmt.Fprintf(os.Stderr, "[tctl] output captured to %s\n", opts.capture)
+			}
+			if opts.measureOutput && res.ExitCode == 0 {
+				after, err := measureOutput(tool.OutputPath())
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(log, "[tctl] ✓ done (%s: %s)\n", filepath.Base(tool.Output), describeMeasure(measureBefore, after))
+			}
+			if opts.profile {
+				printProfile(os.Stderr, tool.Name, res)
+			}
+			if opts.printOutputPath && res.ExitCode == 0 {
+				path, err := absOutputPath(tool, "")
+				if err != nil {
+					return err
+				}
+				fmt.Println(path)
+			}
+			exit(res.ExitCode)
 			return nil
 		},
 	}
 }
+
+// parseRunArgs splits 'tctl run' arguments into tctl options, the tool
+// name, and the arguments passed through to the tool.
+//
+// tctl options come first, up to the tool name or --output <artifact>.
+// Everything after that goes to the tool as is, even a "--" or arguments
+// that look like tctl options. A "--" before the tool name ends tctl's
+// options, so the argument after it is taken as the tool name.
+func parseRunArgs(args []string) (runOptions, string, []string, error) {
+	var opts runOptions
+	var toolName string
+
+	rest, separated := args, false
+	for len(rest) > 0 {
+		if rest[0] == "--" {
+			rest, separated = rest[1:], true
+			break
+		}
+		n, err := parseRunOption(rest, &opts)
+		if err != nil {
+			return opts, "", nil, err
+		}
+		if n == 0 {
+			break
+		}
+		rest = rest[n:]
+	}
+
+	// The tool is given by name or as --output <artifact>
+	switch {
+	case len(rest) == 0:
+		return opts, "", nil, fmt.Errorf("missing tool name")
+	case !separated && (rest[0] == "--output" || strings.HasPrefix(rest[0], "--output=")):
+		artifact, hasValue := strings.CutPrefix(rest[0], "--output=")
+		rest = rest[1:]
+		if !hasValue {
+			if len(rest) == 0 {
+				return opts, "", nil, fmt.Errorf("--output needs an artifact")
+			}
+			artifact, rest = rest[0], rest[1:]
+		}
+		opts.output = artifact
+	case !separated && rest[0] != "-" && strings.HasPrefix(rest[0], "-"):
+		return opts, "", nil, fmt.Errorf("unknown run option: %s (tool arguments go after the tool name)", rest[0])
+	default:
+		toolName, rest = rest[0], rest[1:]
+	}
+	toolArgs := rest
+
+	if opts.argsFile != "" {
+		fileArgs, err := readArgsFile(opts.argsFile)
+		if err != nil {
+			return opts, "", nil, err
+		}
+		toolArgs = append(fileArgs, toolArgs...)
+	}
+	if opts.input != "" && opts.noStdin {
+		return opts, "", nil, fmt.Errorf("--input and --no-stdin can't be combined")
+	}
+	return opts, toolName, toolArgs, nil
+}
+
+// parseRunOption reads the tctl option at the start of args into opts and
+// returns how many arguments it used, or 0 if args[0] isn't a run option.
+func parseRunOption(args []string, opts *runOptions) (int, error) {
+	arg := args[0]
+	switch {
+	case arg == "--explain":
+		opts.explain = true
+	case arg == "--dry-run":
+		opts.dryRun = true
+	case arg == "--profile":
+		opts.profile = true
+	case arg == "--trace":
+		opts.trace = true
+	case arg == "--interactive" || arg == "-i":
+		opts.interactive = true
+	case arg == "--verbose":
+		// Root flags aren't parsed for run, so accept it here too
+		enableVerbose()
+	case arg == "--capture":
+		if len(args) < 2 {
+			return 0, fmt.Errorf("--capture needs a file path")
+		}
+		opts.capture = args[1]
+		return 2, nil
+	case strings.HasPrefix(arg, "--capture="):
+		opts.capture = strings.TrimPrefix(arg, "--capture=")
+	case arg == "--input":
+		if len(args) < 2 {
+			return 0, fmt.Errorf("--input needs a file path")
+		}
+		opts.input = args[1]
+		return 2, nil
+	case strings.HasPrefix(arg, "--input="):
+		opts.input = strings.TrimPrefix(arg, "--input=")
+	case arg == "--timeout" || strings.HasPrefix(arg, "--timeout="):
+		value, hasValue := strings.CutPrefix(arg, "--timeout=")
+		n := 1
+		if !hasValue {
+			if len(args) < 2 {
+				return 0, fmt.Errorf("--timeout needs a duration")
+			}
+			value = args[1]
+			n = 2
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return 0, fmt.Errorf("invalid --timeout %q (want a duration such as 30s or 5m)", value)
+		}
+		opts.timeout = d
+		return n, nil
+	case arg == "--no-stdin":
+		opts.noStdin = true
+	case arg == "--clean-env":
+		opts.cleanEnv = true
+	case arg == "--check-output":
+		opts.checkOutput = true
+	case arg == "--print-output-path":
+		opts.printOutputPath = true
+	case arg == "--measure-output":
+		opts.measureOutput = true
+	case arg == "--args-file" || strings.HasPrefix(arg, "--args-file="),
+		arg == "--env" || strings.HasPrefix(arg, "--env="):
+		return parseArgsFileOrEnv(args, opts)
+	default:
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// parseArgsFileOrEnv reads an --args-file or --env option at the
+// start of args into opts and returns how many arguments it used.
+func parseArgsFileOrEnv(args []string, opts *runOptions) (int, error) {
+	name, value, hasValue := strings.Cut(args[0], "=")
+	n := 1
+	if !hasValue {
+		if len(args) < 2 {
+			if name == "--env" {
+				return 0, fmt.Errorf("--env needs a KEY=VALUE pair")
+			}
+			return 0, fmt.Errorf("%s needs a file path", name)
+		}
+		value = args[1]
+		n = 2
+	}
+
+	switch name {
+	case "--args-file":
+		opts.argsFile = value
+	case "--env":
+		if key, _, ok := strings.Cut(value, "="); !ok || key == "" {
+			return 0, fmt.Errorf("invalid --env %q (want KEY=VALUE)", value)
+		}
+		opts.env = append(opts.env, value)
+	}
+	return n, nil
+}
+
+// readArgsFile reads tool arguments from an args file.
+func readArgsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words, err := util.SplitShellWords(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, i+1, err)
+		}
+		args = append(args, words...)
+	}
+	return args, nil
+}
+
+// isToolPath reports whether arg names an existing file rather than a
+// tool. Bare names only count if they have an extension a scanner handles,
+// so a tool called "report" isn't shadowed by a stray file.
+func isToolPath(arg string) bool {
+	info, err := os.Stat(arg)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return strings.ContainsRune(arg, filepath.Separator) || scanner.GetScanner(arg) != nil
+}
+
+// scanToolFile scans a single tool file into a one-tool registry.
+func scanToolFile(path string) (*tool.Registry, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if scanner.GetScanner(absPath) == nil {
+		return nil, fmt.Errorf("no scanner for %s", path)
+	}
+
+	t, err := scanner.ScanFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("%s is not a tctl tool (no @tool tag in its docstring or sidecar)", path)
+	}
+
+	registry := tool.NewRegistry()
+	registry.Add(t)
+	return registry, nil
+}
+
+// scanStdinTool spools a tool's source from stdin to a temporary file and
+// scans it into a one-tool registry. The file takes each supported
+// extension in turn until a scanner finds a tool in it. The returned
+// function removes the file.
+func scanStdinTool() (*tool.Registry, func(), error) {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "tctl-stdin-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	for _, ext := range scanner.SupportedExtensions() {
+		path := filepath.Join(dir, "stdin"+ext)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		if t, err := scanner.ScanFile(path); err == nil && t != nil {
+			registry := tool.NewRegistry()
+			registry.Add(t)
+			return registry, cleanup, nil
+		}
+		os.Remove(path)
+	}
+	cleanup()
+	return nil, nil, fmt.Errorf("stdin is not a tctl tool (no @tool tag in its docstring)")
+}
+
+// printProfile reports a finished run's wall-clock duration on w,
+// normally stderr.
+func printProfile(w io.Writer, name string, res runner.RunResult) {
+	fmt.Fprintf(w, "[tctl] %s completed in %.2fs (exit %d)\n", name, res.Duration.Seconds(), res.ExitCode)
+}
+
+// printTrace prints a process lifecycle event to stderr for --trace.
+func printTrace(event runner.TraceEvent, detail string) {
+	fmt.Fprintf(os.Stderr, "[tctl] %s trace: %s: %s\n", time.Now().Format("15:04:05.000"), event, detail)
+}
+
+// warnIfDeprecated prints a warning to w, normally stderr, before running
+// a deprecated tool.
+func warnIfDeprecated(w io.Writer, t *tool.Tool) {
+	if !t.Deprecated {
+		return
+	}
+	if t.DeprecatedReason != "" {
+		fmt.Fprintf(w, "[tctl] ⚠ %s is deprecated: %s\n", t.Name, t.DeprecatedReason)
+	} else {
+		fmt.Fprintf(w, "[tctl] ⚠ %s is deprecated\n", t.Name)
+	}
+}
+
+// printRunExplanation prints the tool's parsed metadata followed by how
+// the runner resolved it, including the environment opts give it.
+func printRunExplanation(t *tool.Tool, registry *tool.Registry, args []string, opts runOptions) error {
+	res, err := runner.Resolve(t, args)
+	if err != nil {
+		return err
+	}
+	res.Env = append(res.Env, opts.env...)
+
+	printToolDetails(t, registry)
+
+	fmt.Println("  Runtime:")
+	fmt.Printf("    Runner: %s\n", res.Runner)
+	fmt.Printf("    Interpreter: %s\n", res.Command[0])
+	fmt.Printf("    Command: %s\n", strings.Join(res.Command, " "))
+	fmt.Printf("    Working dir: %s\n", res.Dir)
+	env := "inherited"
+	if opts.cleanEnv {
+		env = "clean (PATH only)"
+	}
+	if len(res.Env) == 0 {
+		fmt.Printf("    Environment: %s\n", env)
+	} else {
+		fmt.Printf("    Environment: %s, plus\n", env)
+		for _, kv := range res.Env {
+			fmt.Printf("      %s\n", kv)
+		}
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// jsonSchema is the subset of a draft-07 JSON Schema that describes a
+// tool's @interface.
+type jsonSchema struct {
+	Schema               string                    `json:"$schema"`
+	Title                string                    `json:"title"`
+	Description          string                    `json:"description,omitempty"`
+	Type                 string                    `json:"type"`
+	Properties           map[string]schemaProperty `json:"properties"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties bool                      `json:"additionalProperties"`
+}
+
+// schemaProperty describes one argument. Arg is the argument as written
+// in @interface ("--symbols", "<path>"), so callers can map values back
+// to a command line.
+type schemaProperty struct {
+	Type        string        `json:"type"`
+	Format      string        `json:"format,omitempty"`
+	Items       *schemaItems  `json:"items,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Arg         string        `json:"x-tctl-arg"`
+	Position    int           `json:"x-tctl-position,omitempty"`
+}
+
+type schemaItems struct {
+	Type string `json:"type"`
+}
+
+// schemaTypes maps @interface types to JSON Schema types. Types not
+// listed (file, path, dir, choice, ...) are strings.
+var schemaTypes = map[string]string{
+	"int":   "integer",
+	"float": "number",
+	"bool":  "boolean",
+	"list":  "array",
+}
+
+// interfaceSchema returns a JSON Schema for t's @interface. Properties are
+// named after the arguments without their "--" or angle brackets.
+func interfaceSchema(t *tool.Tool) jsonSchema {
+	schema := jsonSchema{
+		Schema:      "http://json-schema.org/draft-07/schema#",
+		Title:       t.Name,
+		Description: t.Description,
+		Type:        "object",
+		Properties:  make(map[string]schemaProperty),
+	}
+
+	for _, arg := range t.InterfaceArgs() {
+		name := strings.Trim(strings.TrimPrefix(arg.Name, "--"), "<>")
+
+		typ := schemaTypes[arg.Type]
+		if typ == "" {
+			typ = "string"
+		}
+		prop := schemaProperty{
+			Type:        typ,
+			Description: arg.Description,
+			Arg:         arg.Name,
+			Position:    arg.Position,
+		}
+		switch arg.Type {
+		case "list":
+			prop.Items = &schemaItems{Type: "string"}
+		case "date":
+			prop.Format = "date"
+		}
+
+		if arg.Default != "" {
+			prop.Default = schemaValue(typ, arg.Default)
+		}
+		for _, choice := range arg.Choices {
+			prop.Enum = append(prop.Enum, schemaValue(typ, choice))
+		}
+
+		schema.Properties[name] = prop
+		if arg.Required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	sort.Strings(schema.Required)
+
+	return schema
+}
+
+// schemaValue converts a default or choice to typ, keeping it as a string
+// if it doesn't parse.
+func schemaValue(typ, value string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case "array":
+		return strings.Split(value, ",")
+	}
+	return value
+}
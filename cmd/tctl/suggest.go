@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/yourname/tctl/internal/util"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// maxSuggestions is how many names a "Did you mean" hint lists.
+const maxSuggestions = 3
+
+// didYouMean returns a hint naming the candidates closest to a mistyped
+// name, e.g. "Did you mean: fetch-prices?", or "" if none is close. Longer
+// names tolerate more typos: one edit per four characters, at least two.
+func didYouMean(name string, candidates []string) string {
+	closest := util.Closest(name, candidates, max(2, len(name)/4))
+	if len(closest) == 0 {
+		return ""
+	}
+	if len(closest) > maxSuggestions {
+		closest = closest[:maxSuggestions]
+	}
+	return "Did you mean: " + strings.Join(closest, ", ") + "?"
+}
+
+// unknownToolError is the error for a tool name that isn't in registry,
+// with a hint naming any close matches.
+func unknownToolError(name string, registry *tool.Registry) error {
+	msg := "unknown tool: " + name
+	if hint := didYouMean(name, toolNames(registry)); hint != "" {
+		msg += "\n" + hint
+	}
+	return errors.New(msg + "\nRun 'tctl list' to see available tools.")
+}
+
+// toolNames returns the name of every tool in registry.
+func toolNames(registry *tool.Registry) []string {
+	var names []string
+	for _, t := range registry.All() {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// artifactNames returns every artifact the tools in registry provide.
+func artifactNames(registry *tool.Registry) []string {
+	var names []string
+	for _, t := range registry.All() {
+		names = append(names, t.Provides...)
+	}
+	return names
+}
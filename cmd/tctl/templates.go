@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// toolTemplate is the skeleton 'tctl new' writes for one language.
+// Each body is a format string filled with the file name, tool name,
+// description, and keywords, in that order.
+type toolTemplate struct {
+	extension string // file extension, including the dot
+	separator string // replaces "-" in the tool name to form the file name
+	body      string
+}
+
+// toolTemplates holds the available templates, keyed by language. Only
+// languages with both a scanner and a runner have one, so a new tool
+// shows up in 'tctl list' and runs.
+var toolTemplates = map[string]toolTemplate{
+	"python":     {extension: ".py", separator: "_", body: pythonToolTemplate},
+	"typescript": {extension: ".ts", separator: "-", body: typescriptToolTemplate},
+}
+
+// getToolTemplate returns the template for lang, or an error listing the
+// available languages.
+func getToolTemplate(lang string) (toolTemplate, error) {
+	if t, ok := toolTemplates[strings.ToLower(lang)]; ok {
+		return t, nil
+	}
+	return toolTemplate{}, fmt.Errorf("no template for language '%s' (available: %s)",
+		lang, strings.Join(templateLanguages(), ", "))
+}
+
+// templateLanguages returns the languages with a template, sorted.
+func templateLanguages() []string {
+	var langs []string
+	for lang := range toolTemplates {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// fileName returns the file name for a tool called toolName.
+func (t toolTemplate) fileName(toolName string) string {
+	return strings.ReplaceAll(toolName, "-", t.separator) + t.extension
+}
+
+const pythonToolTemplate = `#!/usr/bin/env python3
+"""
+%[1]s
+
+%[3]s
+
+@tool %[2]s
+@version 0.1.0
+@provides TODO-data-name
+@requires
+@output data/TODO-output.csv
+@freshness daily
+
+@capability TODO: Describe what this tool does
+@capability TODO: Add more capabilities
+
+@boundary TODO: Does NOT do X (use other-tool for that)
+
+@keywords %[4]s
+
+@interface
+  --out: file, required - Output file path
+
+@example tctl run %[2]s --out data/output.csv
+"""
+
+import argparse
+import sys
+
+
+def main():
+    ap = argparse.ArgumentParser(description="TODO: Description")
+    ap.add_argument("--out", required=True, help="Output file path")
+    args = ap.parse_args()
+
+    # TODO: Implement tool logic
+    print(f"TODO: Implement %[2]s")
+    print(f"Output would go to: {args.out}")
+
+
+if __name__ == "__main__":
+    main()
+`
+
+const typescriptToolTemplate = `/**
+ * %[1]s
+ *
+ * %[3]s
+ *
+ * @tool %[2]s
+ * @version 0.1.0
+ * @provides TODO-data-name
+ * @requires
+ * @output data/TODO-output.csv
+ * @freshness daily
+ *
+ * @capability TODO: Describe what this tool does
+ * @capability TODO: Add more capabilities
+ *
+ * @boundary TODO: Does NOT do X (use other-tool for that)
+ *
+ * @keywords %[4]s
+ *
+ * @interface
+ *   --out: file, required - Output file path
+ *
+ * @example tctl run %[2]s --out data/output.csv
+ */
+
+import { parseArgs } from "node:util";
+
+function main(): void {
+  const { values } = parseArgs({ options: { out: { type: "string" } } });
+  if (!values.out) {
+    console.error("--out is required");
+    process.exit(2);
+  }
+
+  // TODO: Implement tool logic
+  console.log("TODO: Implement %[2]s");
+  console.log(` + "`Output would go to: ${values.out}`" + `);
+}
+
+main();
+`
@@ -3,6 +3,7 @@ module github.com/yourname/tctl
 go 1.25
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/spf13/cobra v1.8.0
 	gopkg.in/yaml.v3 v3.0.1
 )
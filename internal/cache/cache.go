@@ -0,0 +1,70 @@
+// Package cache keeps the tools found by the last 'tctl sync' in the
+// config directory, so later commands can see what changed since.
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// Snapshot is the cached result of a scan.
+type Snapshot struct {
+	Synced time.Time    `yaml:"synced"`
+	Tools  []*tool.Tool `yaml:"tools"`
+}
+
+// Path returns the location of the cache file.
+func Path() string {
+	return filepath.Join(config.ConfigDir(), config.CacheFile)
+}
+
+// Save replaces the cache with the tools in registry.
+func Save(registry *tool.Registry) error {
+	snap := Snapshot{Synced: time.Now(), Tools: registry.All()}
+	sort.Slice(snap.Tools, func(i, j int) bool {
+		return snap.Tools[i].Name < snap.Tools[j].Name
+	})
+
+	data, err := yaml.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if err := config.EnsureConfigDir(); err != nil {
+		return err
+	}
+	return os.WriteFile(Path(), data, 0644)
+}
+
+// Load reads the cache. It returns nil if nothing has been synced yet.
+func Load() (*Snapshot, error) {
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := yaml.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+// Get returns the cached tool with the given name, or nil.
+func (s *Snapshot) Get(name string) *tool.Tool {
+	for _, t := range s.Tools {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
@@ -5,10 +5,15 @@ package config
 import (
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/yourname/tctl/internal/util"
 )
 
 const (
@@ -16,13 +21,51 @@ const (
 	SourcesFile    = "sources.yaml"
 	CacheFile      = "cache.yaml"
 	SettingsFile   = "settings.yaml"
+	RunLogFile     = "runs.jsonl"
+	LintFile       = "lint.yaml"
+	SynonymsFile   = "synonyms.yaml"
+	StopWordsFile  = "stopwords.txt"
 )
 
 // Source represents a registered tool directory.
+// When two sources define a tool with the same name, the source with the
+// higher Priority wins; ties go to the source registered last.
 type Source struct {
-	Path    string    `yaml:"path"`
-	Name    string    `yaml:"name,omitempty"`
-	Added   time.Time `yaml:"added"`
+	Path     string    `yaml:"path"`
+	Name     string    `yaml:"name,omitempty"`
+	Added    time.Time `yaml:"added"`
+	Enabled  bool      `yaml:"enabled"`
+	Priority int       `yaml:"priority,omitempty"`
+}
+
+// UnmarshalYAML decodes a source, defaulting Enabled to true so that
+// entries written before the field existed stay active.
+func (s *Source) UnmarshalYAML(value *yaml.Node) error {
+	type rawSource Source
+	raw := rawSource{Enabled: true}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	*s = Source(raw)
+	return nil
+}
+
+// Dir returns the source directory with ~ and environment variables
+// expanded. Path keeps the form written in sources.yaml.
+func (s Source) Dir() string {
+	if dir, err := ExpandPath(s.Path); err == nil {
+		return dir
+	}
+	return s.Path
+}
+
+// matches reports whether pathOrName refers to this source.
+func (s Source) matches(pathOrName string) bool {
+	if s.Name == pathOrName || s.Path == pathOrName {
+		return true
+	}
+	absPath, err := ExpandPath(pathOrName)
+	return err == nil && s.Dir() == absPath
 }
 
 // Sources holds all registered tool directories.
@@ -33,12 +76,59 @@ type Sources struct {
 // Settings holds global tctl settings.
 type Settings struct {
 	DefaultLanguage string `yaml:"default_language,omitempty"`
+
+	// KeepStopWords are built-in stop words to treat as keywords after all.
+	KeepStopWords []string `yaml:"keep_stop_words,omitempty"`
+}
+
+// SettingKeys lists the keys accepted by Settings.Get and Settings.Set.
+var SettingKeys = []string{
+	"default_language",
+	"keep_stop_words",
+}
+
+// Get returns the value of a setting by its settings.yaml key. Lists are
+// returned comma-separated.
+func (s *Settings) Get(key string) (string, error) {
+	switch key {
+	case "default_language":
+		return s.DefaultLanguage, nil
+	case "keep_stop_words":
+		return strings.Join(s.KeepStopWords, ","), nil
+	}
+	return "", unknownSettingError(key)
+}
+
+// Set updates a setting by its settings.yaml key. Lists are given
+// comma-separated; an empty value clears them.
+func (s *Settings) Set(key, value string) error {
+	switch key {
+	case "default_language":
+		s.DefaultLanguage = value
+		return nil
+	case "keep_stop_words":
+		s.KeepStopWords = nil
+		for _, word := range strings.Split(value, ",") {
+			if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+				s.KeepStopWords = append(s.KeepStopWords, word)
+			}
+		}
+		return nil
+	}
+	return unknownSettingError(key)
+}
+
+func unknownSettingError(key string) error {
+	return fmt.Errorf("unknown setting: %s (valid keys: %s)", key, strings.Join(SettingKeys, ", "))
 }
 
 // Intent represents a named workflow.
 type Intent struct {
 	Description string   `yaml:"description,omitempty"`
 	Includes    []string `yaml:"includes,omitempty"`
+
+	// Source is the state.yaml file that defined this intent.
+	Source string `yaml:"-"`
 }
 
 // Intents holds all defined intents (loaded from any source).
@@ -46,12 +136,38 @@ type Intents struct {
 	Intents map[string]Intent `yaml:"intents,omitempty"`
 }
 
+// DefaultSynonyms are the search synonyms tctl ships with. Entries in
+// synonyms.yaml are added to them. Synonyms work in both directions.
+var DefaultSynonyms = map[string][]string{
+	"k8s":    {"kubernetes"},
+	"logs":   {"logging", "log"},
+	"db":     {"database", "sql"},
+	"auth":   {"authentication", "login"},
+	"config": {"configuration", "settings"},
+	"repo":   {"repository", "git"},
+	"notify": {"notification", "alert", "email"},
+	"stats":  {"statistics", "metrics"},
+	"docs":   {"documentation", "readme"},
+}
+
 // Global represents the global tctl configuration.
 type Global struct {
 	ConfigDir string
 	Sources   *Sources
 	Settings  *Settings
 	Intents   *Intents
+
+	// Synonyms maps a search word to words that should match it too.
+	Synonyms map[string][]string
+
+	// StopWords are the words left out of extracted keywords: the
+	// built-in util.StopWords, minus Settings.KeepStopWords, plus the
+	// words in stopwords.txt.
+	StopWords map[string]bool
+
+	// Warnings describe problems that don't stop tctl from loading, such
+	// as an enabled source whose path names an unset variable.
+	Warnings []string
 }
 
 // ConfigDir returns the tctl config directory path.
@@ -82,6 +198,22 @@ func Load() (*Global, error) {
 		Sources:   &Sources{Sources: []Source{}},
 		Settings:  &Settings{DefaultLanguage: "python"},
 		Intents:   &Intents{Intents: make(map[string]Intent)},
+		Synonyms:  make(map[string][]string),
+	}
+
+	// Load synonyms: the defaults plus the user's own
+	for word, synonyms := range DefaultSynonyms {
+		g.Synonyms[word] = append([]string{}, synonyms...)
+	}
+	synonymsPath := filepath.Join(dir, SynonymsFile)
+	if data, err := os.ReadFile(synonymsPath); err == nil {
+		var userSynonyms map[string][]string
+		if err := yaml.Unmarshal(data, &userSynonyms); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", synonymsPath, err)
+		}
+		for word, synonyms := range userSynonyms {
+			g.Synonyms[word] = append(g.Synonyms[word], synonyms...)
+		}
 	}
 
 	// Load sources
@@ -96,13 +228,25 @@ func Load() (*Global, error) {
 		yaml.Unmarshal(data, g.Settings)
 	}
 
-	// Load intents from all sources that have state.yaml
+	g.StopWords = loadStopWords(filepath.Join(dir, StopWordsFile), g.Settings.KeepStopWords)
+
+	for _, src := range g.Sources.Sources {
+		if _, err := ExpandPath(src.Path); err != nil && src.Enabled {
+			g.Warnings = append(g.Warnings, fmt.Sprintf("source %s is skipped: %v", src.Name, err))
+		}
+	}
+
+	// Load intents from all enabled sources that have state.yaml
 	for _, src := range g.Sources.Sources {
-		statePath := filepath.Join(filepath.Dir(src.Path), "state.yaml")
+		if !src.Enabled {
+			continue
+		}
+		statePath := filepath.Join(filepath.Dir(src.Dir()), "state.yaml")
 		if data, err := os.ReadFile(statePath); err == nil {
 			var srcIntents Intents
 			if yaml.Unmarshal(data, &srcIntents) == nil {
 				for name, intent := range srcIntents.Intents {
+					intent.Source = statePath
 					g.Intents.Intents[name] = intent
 				}
 			}
@@ -112,29 +256,121 @@ func Load() (*Global, error) {
 	return g, nil
 }
 
+// loadStopWords returns the built-in stop words without keep, plus the
+// words listed in path: whitespace-separated, with # starting a comment.
+func loadStopWords(path string, keep []string) map[string]bool {
+	words := make(map[string]bool)
+	for word := range util.StopWords {
+		words[word] = true
+	}
+	for _, word := range keep {
+		delete(words, strings.ToLower(word))
+	}
+
+	if data, err := os.ReadFile(path); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			line, _, _ = strings.Cut(line, "#")
+			for _, word := range strings.Fields(line) {
+				words[strings.ToLower(word)] = true
+			}
+		}
+	}
+	return words
+}
+
 // Save saves the sources configuration.
 func (g *Global) Save() error {
+	return g.writeYAML(SourcesFile, g.Sources)
+}
+
+// SaveSettings saves the global settings.
+func (g *Global) SaveSettings() error {
+	return g.writeYAML(SettingsFile, g.Settings)
+}
+
+// SaveAll saves both sources and settings.
+// Intents are not written: they belong to each source's state.yaml.
+func (g *Global) SaveAll() error {
+	if err := g.Save(); err != nil {
+		return err
+	}
+	return g.SaveSettings()
+}
+
+// writeYAML marshals v into the named file in the config directory.
+func (g *Global) writeYAML(name string, v interface{}) error {
 	if err := EnsureConfigDir(); err != nil {
 		return err
 	}
 
-	// Save sources
-	sourcesPath := filepath.Join(g.ConfigDir, SourcesFile)
-	data, err := yaml.Marshal(g.Sources)
+	data, err := yaml.Marshal(v)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(sourcesPath, data, 0644)
+	return os.WriteFile(filepath.Join(g.ConfigDir, name), data, 0644)
+}
+
+// ExpandPath expands a leading ~ or ~user and any $VAR or ${VAR}
+// references in path, then resolves it to an absolute path. A variable
+// that isn't set is an error rather than an empty string, so "$WORK/tools"
+// doesn't quietly become "/tools".
+func ExpandPath(path string) (string, error) {
+	var unset string
+	expanded := os.Expand(path, func(name string) string {
+		value, ok := os.LookupEnv(name)
+		if !ok && unset == "" {
+			unset = name
+		}
+		return value
+	})
+	if unset != "" {
+		return "", fmt.Errorf("%s: environment variable $%s is not set", path, unset)
+	}
+	path = expanded
+
+	if strings.HasPrefix(path, "~") {
+		rest := path[1:]
+		userName := rest
+		if idx := strings.IndexRune(rest, filepath.Separator); idx != -1 {
+			userName = rest[:idx]
+		}
+		rest = rest[len(userName):]
+
+		var home string
+		if userName == "" {
+			h, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			home = h
+		} else {
+			u, err := user.Lookup(userName)
+			if err != nil {
+				return "", err
+			}
+			home = u.HomeDir
+		}
+		path = home + rest
+	}
+
+	return filepath.Abs(path)
 }
 
 // AddSource adds a new source directory.
+// Paths written with ~ or environment variables are stored as given so
+// sources.yaml stays portable; all other paths are stored absolute.
 func (g *Global) AddSource(path, name string) error {
 	// Resolve to absolute path
-	absPath, err := filepath.Abs(path)
+	absPath, err := ExpandPath(path)
 	if err != nil {
 		return err
 	}
 
+	storedPath := absPath
+	if strings.HasPrefix(path, "~") || strings.Contains(path, "$") {
+		storedPath = path
+	}
+
 	// Check it exists
 	info, err := os.Stat(absPath)
 	if err != nil {
@@ -146,7 +382,7 @@ func (g *Global) AddSource(path, name string) error {
 
 	// Check if already registered
 	for _, src := range g.Sources.Sources {
-		if src.Path == absPath {
+		if src.Dir() == absPath {
 			return fmt.Errorf("already registered: %s", absPath)
 		}
 	}
@@ -157,9 +393,10 @@ func (g *Global) AddSource(path, name string) error {
 	}
 
 	g.Sources.Sources = append(g.Sources.Sources, Source{
-		Path:  absPath,
-		Name:  name,
-		Added: time.Now(),
+		Path:    storedPath,
+		Name:    name,
+		Added:   time.Now(),
+		Enabled: true,
 	})
 
 	return g.Save()
@@ -167,13 +404,11 @@ func (g *Global) AddSource(path, name string) error {
 
 // RemoveSource removes a source directory.
 func (g *Global) RemoveSource(pathOrName string) error {
-	absPath, _ := filepath.Abs(pathOrName)
-
 	var newSources []Source
 	found := false
 
 	for _, src := range g.Sources.Sources {
-		if src.Path == absPath || src.Name == pathOrName {
+		if src.matches(pathOrName) {
 			found = true
 			continue
 		}
@@ -188,11 +423,51 @@ func (g *Global) RemoveSource(pathOrName string) error {
 	return g.Save()
 }
 
-// SourcePaths returns all registered source paths.
+// SetSourceEnabled enables or disables a source without unregistering it.
+func (g *Global) SetSourceEnabled(pathOrName string, enabled bool) error {
+	for i := range g.Sources.Sources {
+		src := &g.Sources.Sources[i]
+		if src.matches(pathOrName) {
+			src.Enabled = enabled
+			return g.Save()
+		}
+	}
+
+	return fmt.Errorf("not registered: %s", pathOrName)
+}
+
+// SetSourcePriority sets the collision priority of a source.
+func (g *Global) SetSourcePriority(pathOrName string, priority int) error {
+	for i := range g.Sources.Sources {
+		src := &g.Sources.Sources[i]
+		if src.matches(pathOrName) {
+			src.Priority = priority
+			return g.Save()
+		}
+	}
+
+	return fmt.Errorf("not registered: %s", pathOrName)
+}
+
+// SourcePaths returns the expanded paths of all enabled sources in ascending
+// priority order. Scanning them in this order lets higher-priority sources
+// override tools of the same name from lower-priority ones. Sources whose
+// paths can't be expanded are left out (see Warnings).
 func (g *Global) SourcePaths() []string {
-	paths := make([]string, len(g.Sources.Sources))
-	for i, src := range g.Sources.Sources {
-		paths[i] = src.Path
+	var enabled []Source
+	for _, src := range g.Sources.Sources {
+		if _, err := ExpandPath(src.Path); src.Enabled && err == nil {
+			enabled = append(enabled, src)
+		}
+	}
+
+	sort.SliceStable(enabled, func(i, j int) bool {
+		return enabled[i].Priority < enabled[j].Priority
+	})
+
+	paths := make([]string, len(enabled))
+	for i, src := range enabled {
+		paths[i] = src.Dir()
 	}
 	return paths
 }
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourname/tctl/internal/util"
+)
+
+func TestLoadStopWordsDefaults(t *testing.T) {
+	words := loadStopWords(filepath.Join(t.TempDir(), StopWordsFile), nil)
+	if len(words) != len(util.StopWords) {
+		t.Errorf("got %d stop words, want the %d built-in ones", len(words), len(util.StopWords))
+	}
+	for word := range util.StopWords {
+		if !words[word] {
+			t.Errorf("built-in stop word %q is missing", word)
+		}
+	}
+}
+
+func TestLoadStopWordsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StopWordsFile)
+	data := "# Words too common in our tools\ndata  Tool\n\nscript # trailing comment\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	words := loadStopWords(path, nil)
+	for _, word := range []string{"data", "tool", "script", "the"} {
+		if !words[word] {
+			t.Errorf("%q should be a stop word", word)
+		}
+	}
+	for _, word := range []string{"#", "words", "trailing", "comment", "Tool"} {
+		if words[word] {
+			t.Errorf("%q should not be a stop word", word)
+		}
+	}
+}
+
+func TestLoadStopWordsKeep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StopWordsFile)
+	if err := os.WriteFile(path, []byte("data\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	words := loadStopWords(path, []string{"for", "FROM", "data"})
+	for _, word := range []string{"for", "from"} {
+		if words[word] {
+			t.Errorf("kept word %q is still a stop word", word)
+		}
+	}
+	if !words["the"] {
+		t.Error("a built-in stop word that wasn't kept is missing")
+	}
+	// Keeping only applies to built-in words; stopwords.txt always wins
+	if !words["data"] {
+		t.Error("a word listed in stopwords.txt should stay a stop word")
+	}
+}
+
+func TestLoadKeepStopWordsSetting(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	dir := filepath.Join(xdg, ConfigDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SettingsFile), []byte("keep_stop_words: [for]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.StopWords["for"] {
+		t.Error("keep_stop_words in settings.yaml should keep 'for'")
+	}
+	if !g.StopWords["the"] {
+		t.Error("'the' should still be a stop word")
+	}
+}
+
+func TestLoadSynonyms(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	dir := filepath.Join(xdg, ConfigDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, SynonymsFile)
+
+	if err := os.WriteFile(path, []byte("k8s: [kube]\ngpu: [cuda]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Synonyms["k8s"]; len(got) != 2 || got[0] != "kubernetes" || got[1] != "kube" {
+		t.Errorf("k8s synonyms = %v, want the default plus kube", got)
+	}
+	if got := g.Synonyms["gpu"]; len(got) != 1 || got[0] != "cuda" {
+		t.Errorf("gpu synonyms = %v, want [cuda]", got)
+	}
+
+	if err := os.WriteFile(path, []byte("k8s: kube: [\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("Load should fail on a malformed synonyms.yaml")
+	}
+}
+
+func TestSaveSettingsRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	g, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Settings.Set("default_language", "typescript"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Settings.Set("keep_stop_words", "for, From"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.SaveSettings(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(filepath.Join(ConfigDir(), SettingsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o644 {
+		t.Errorf("settings.yaml mode = %o, want 644", perm)
+	}
+
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Settings.DefaultLanguage; got != "typescript" {
+		t.Errorf("DefaultLanguage = %q after reload, want typescript", got)
+	}
+	if got, _ := reloaded.Settings.Get("keep_stop_words"); got != "for,from" {
+		t.Errorf("keep_stop_words = %q after reload, want for,from", got)
+	}
+}
+
+func TestSaveAllRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	tools := t.TempDir()
+
+	g, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddSource(tools, "mine"); err != nil {
+		t.Fatal(err)
+	}
+	g.Settings.DefaultLanguage = "typescript"
+	if err := g.SaveAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src := reloaded.FindSourceByName("mine"); src == nil || src.Dir() != tools {
+		t.Errorf("source after reload = %+v, want mine at %s", src, tools)
+	}
+	if got := reloaded.Settings.DefaultLanguage; got != "typescript" {
+		t.Errorf("DefaultLanguage = %q after reload, want typescript", got)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // os.UserHomeDir on Windows
+	t.Setenv("WORK", "work")
+	t.Setenv("TCTL_TEST_UNSET", "")
+	os.Unsetenv("TCTL_TEST_UNSET")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "~", want: home},
+		{path: "~/tools", want: filepath.Join(home, "tools")},
+		{path: "$HOME/x", want: filepath.Join(home, "x")},
+		{path: "${HOME}/x", want: filepath.Join(home, "x")},
+		{path: "~/$WORK/scripts", want: filepath.Join(home, "work", "scripts")},
+		{path: "$HOME/${WORK}", want: filepath.Join(home, "work")},
+	}
+	for _, tt := range tests {
+		got, err := ExpandPath(filepath.FromSlash(tt.path))
+		if err != nil {
+			t.Errorf("ExpandPath(%q): %v", tt.path, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	for _, path := range []string{"$TCTL_TEST_UNSET/tools", "~/${TCTL_TEST_UNSET}"} {
+		if got, err := ExpandPath(path); err == nil {
+			t.Errorf("ExpandPath(%q) = %q, want an error for the unset variable", path, got)
+		}
+	}
+}
+
+func TestSourceWithUnsetVariable(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("TCTL_TEST_UNSET", "")
+	os.Unsetenv("TCTL_TEST_UNSET")
+	dir := filepath.Join(xdg, ConfigDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tools := t.TempDir()
+	sources := "sources:\n  - path: $TCTL_TEST_UNSET/tools\n    name: broken\n  - path: " + tools + "\n    name: ok\n"
+	if err := os.WriteFile(filepath.Join(dir, SourcesFile), []byte(sources), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Warnings) != 1 {
+		t.Errorf("Warnings = %v, want one about the broken source", g.Warnings)
+	}
+	if paths := g.SourcePaths(); len(paths) != 1 || paths[0] != tools {
+		t.Errorf("SourcePaths = %v, want only %s", paths, tools)
+	}
+	if err := g.AddSource("$TCTL_TEST_UNSET/more", ""); err == nil {
+		t.Error("AddSource should fail for a path with an unset variable")
+	}
+}
@@ -2,9 +2,12 @@
 package freshness
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -16,10 +19,22 @@ var Thresholds = map[string]time.Duration{
 	"manual":  365 * 24 * time.Hour * 100, // ~100 years, effectively never stale
 }
 
+// Limits on how much of an output directory is searched for its newest
+// file, so a huge tree doesn't stall 'tctl status'.
+const (
+	MaxDirDepth = 4     // levels below the output directory
+	MaxDirFiles = 10000 // files examined before giving up and using the newest so far
+)
+
+// errStopWalk ends a directory walk early once MaxDirFiles is reached.
+var errStopWalk = errors.New("stop walk")
+
 // Check determines if a file is fresh based on the freshness policy.
-// Returns (isFresh, statusMessage).
+// A directory is as fresh as the newest file in it, and missing if it
+// holds no files. A glob pattern is as fresh as its newest match, and
+// missing if nothing matches. Returns (isFresh, statusMessage).
 func Check(path string, freshnessPolicy string) (bool, string) {
-	info, err := os.Stat(path)
+	modTime, err := ModTime(path)
 	if os.IsNotExist(err) {
 		return false, "missing"
 	}
@@ -27,7 +42,7 @@ func Check(path string, freshnessPolicy string) (bool, string) {
 		return false, fmt.Sprintf("error: %v", err)
 	}
 
-	age := time.Since(info.ModTime())
+	age := time.Since(modTime)
 	maxAge, ok := Thresholds[freshnessPolicy]
 	if !ok {
 		maxAge = Thresholds["manual"]
@@ -39,6 +54,97 @@ func Check(path string, freshnessPolicy string) (bool, string) {
 	return false, formatAge(age, "stale")
 }
 
+// ModTime returns when the data at path was last written: the modification
+// time of a file, or of the newest file in a directory (searched up to
+// MaxDirDepth levels and MaxDirFiles files). An empty directory reports
+// fs.ErrNotExist. If path is a glob pattern, it is the ModTime of the
+// newest match.
+func ModTime(path string) (time.Time, error) {
+	if IsGlob(path) {
+		newest, err := Newest(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		path = newest
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !info.IsDir() {
+		return info.ModTime(), nil
+	}
+
+	var newest time.Time
+	seen := 0
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // unreadable entries don't count
+		}
+		if d.IsDir() {
+			if rel, _ := filepath.Rel(path, p); rel != "." && depth(rel) > MaxDirDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		if seen++; seen >= MaxDirFiles {
+			return errStopWalk
+		}
+		return nil
+	})
+	if err != nil && err != errStopWalk {
+		return time.Time{}, err
+	}
+	if newest.IsZero() {
+		return time.Time{}, fs.ErrNotExist
+	}
+	return newest, nil
+}
+
+// IsGlob reports whether path is a filepath.Match pattern, as in an
+// "@output data/report-*.csv" that names a new file each run.
+func IsGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// Newest returns the most recently modified path matching pattern. It
+// reports fs.ErrNotExist if nothing matches.
+func Newest(pattern string) (string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var newest string
+	var newestTime time.Time
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		if newest == "" || info.ModTime().After(newestTime) {
+			newest, newestTime = match, info.ModTime()
+		}
+	}
+	if newest == "" {
+		return "", fs.ErrNotExist
+	}
+	return newest, nil
+}
+
+// depth returns how many directories deep a relative path is.
+func depth(rel string) int {
+	return strings.Count(filepath.ToSlash(rel), "/") + 1
+}
+
 // CheckWithRoot checks freshness using a path relative to projectRoot.
 func CheckWithRoot(projectRoot, relativePath, freshnessPolicy string) (bool, string) {
 	fullPath := filepath.Join(projectRoot, relativePath)
@@ -0,0 +1,98 @@
+package freshness
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFiles creates each named file in dir, the first oldest, one hour
+// apart.
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	base := time.Now().Add(-time.Duration(len(names)) * time.Hour)
+	for i, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := base.Add(time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestIsGlob(t *testing.T) {
+	tests := map[string]bool{
+		"data/report.csv":      false,
+		"data/report-*.csv":    true,
+		"data/report-?.csv":    true,
+		"data/report-[ab].csv": true,
+		"":                     false,
+	}
+	for path, want := range tests {
+		if got := IsGlob(path); got != want {
+			t.Errorf("IsGlob(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestNewestNoMatches(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "other.csv")
+
+	_, err := Newest(filepath.Join(dir, "report-*.csv"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Newest err = %v, want fs.ErrNotExist", err)
+	}
+	if fresh, status := Check(filepath.Join(dir, "report-*.csv"), "daily"); fresh || status != "missing" {
+		t.Errorf("Check = %v, %q; want false, \"missing\"", fresh, status)
+	}
+}
+
+func TestNewestOneMatch(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "report-2024-01.csv", "other.csv")
+
+	got, err := Newest(filepath.Join(dir, "report-*.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "report-2024-01.csv"); got != want {
+		t.Errorf("Newest = %q, want %q", got, want)
+	}
+	if fresh, status := Check(filepath.Join(dir, "report-*.csv"), "daily"); !fresh || !strings.HasPrefix(status, "fresh") {
+		t.Errorf("Check = %v, %q; want fresh", fresh, status)
+	}
+}
+
+func TestNewestSeveralMatches(t *testing.T) {
+	dir := t.TempDir()
+	// Written oldest first, so the newest isn't the last by name
+	writeFiles(t, dir, "report-2024-03.csv", "report-2024-01.csv", "report-2024-02.csv")
+
+	got, err := Newest(filepath.Join(dir, "report-*.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "report-2024-02.csv"); got != want {
+		t.Errorf("Newest = %q, want %q", got, want)
+	}
+
+	modTime, err := ModTime(filepath.Join(dir, "report-*.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !modTime.Equal(info.ModTime()) {
+		t.Errorf("ModTime = %v, want the newest match's %v", modTime, info.ModTime())
+	}
+}
@@ -6,9 +6,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourname/tctl/internal/config"
 	"github.com/yourname/tctl/internal/scanner"
+	"github.com/yourname/tctl/internal/util"
+	"github.com/yourname/tctl/pkg/tool"
 )
 
 // Level represents the severity of a lint finding.
@@ -18,8 +25,15 @@ const (
 	LevelError   Level = "error"
 	LevelWarning Level = "warning"
 	LevelInfo    Level = "info"
+
+	// LevelOff disables a rule. It is only valid in lint configs.
+	LevelOff Level = "off"
 )
 
+// ProjectConfigFile is the per-project lint config. It is looked up from
+// the linted path upward and overrides the global lint.yaml.
+const ProjectConfigFile = ".tctl-lint.yaml"
+
 // Message represents a single lint finding.
 type Message struct {
 	Level   Level
@@ -42,6 +56,19 @@ type Result struct {
 	Errors   []Message
 	Warnings []Message
 	Info     []Message
+
+	// severities overrides the default level of rules by code.
+	severities map[string]Level
+
+	// suppressions holds the "# tctl:disable" directives of each file,
+	// keyed by the file's display path and then by code.
+	suppressions map[string]map[string]*suppression
+}
+
+// suppression is a code disabled by a "# tctl:disable" comment.
+type suppression struct {
+	line int
+	used bool
 }
 
 // OK returns true if there are no errors.
@@ -49,8 +76,25 @@ func (r *Result) OK() bool {
 	return len(r.Errors) == 0
 }
 
+// Merge appends other's findings to r, as when linting several paths
+// into one report.
+func (r *Result) Merge(other *Result) {
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Warnings = append(r.Warnings, other.Warnings...)
+	r.Info = append(r.Info, other.Info...)
+}
+
 // Add adds a finding to the result.
+// A configured severity for the code replaces level; "off" drops the finding.
+// Codes suppressed in file by a "# tctl:disable" comment are dropped.
 func (r *Result) Add(level Level, file string, line int, code, message string) {
+	if sup := r.suppressions[file][code]; sup != nil {
+		sup.used = true
+		return
+	}
+	if override, ok := r.severities[code]; ok {
+		level = override
+	}
 	msg := Message{Level: level, File: file, Line: line, Code: code, Message: message}
 	switch level {
 	case LevelError:
@@ -62,9 +106,231 @@ func (r *Result) Add(level Level, file string, line int, code, message string) {
 	}
 }
 
+// suppressDirective marks a comment that disables lint codes for a file,
+// e.g. "# tctl:disable T004,T010", or "// tctl:disable T004" in
+// TypeScript. The codes end at the first word that isn't one, so a reason
+// can follow: "# tctl:disable T004 legacy tool".
+const suppressDirective = "tctl:disable"
+
+// lintCode matches a lint code such as T004.
+var lintCode = regexp.MustCompile(`^[A-Z]\d{3}$`)
+
+// delimited is a string or block comment: text between open and close in
+// which a comment marker doesn't start a comment.
+type delimited struct {
+	open, close string
+	multiline   bool // it can continue on the next line
+	escapes     bool // a backslash escapes the next character
+}
+
+// commentSyntax is how a language writes line comments, and the strings
+// and block comments that can hide a comment marker.
+type commentSyntax struct {
+	marker    string
+	delimited []delimited // longest opening delimiters first
+}
+
+var (
+	pythonComments = commentSyntax{
+		marker: "#",
+		delimited: []delimited{
+			{`"""`, `"""`, true, true},
+			{`'''`, `'''`, true, true},
+			{`"`, `"`, false, true},
+			{`'`, `'`, false, true},
+		},
+	}
+	typeScriptComments = commentSyntax{
+		marker: "//",
+		delimited: []delimited{
+			{"/*", "*/", true, false},
+			{"`", "`", true, true},
+			{`"`, `"`, false, true},
+			{`'`, `'`, false, true},
+		},
+	}
+)
+
+// loadSuppressions reads the "# tctl:disable" comments ("//" in
+// TypeScript) in path and registers them under file, the path findings
+// are reported under. The directive counts in a comment of its own or
+// after code, but not inside a string.
+func (r *Result) loadSuppressions(path, file string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	syntax := pythonComments
+	if isTypeScript(path) {
+		syntax = typeScriptComments
+	}
+
+	lineNum := 0
+	var open *delimited
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		start := syntax.commentStart(line, &open)
+		if start == -1 {
+			continue
+		}
+		comment := strings.TrimSpace(line[start+len(syntax.marker):])
+		if !strings.HasPrefix(comment, suppressDirective) {
+			continue
+		}
+
+		words := strings.FieldsFunc(comment[len(suppressDirective):], func(c rune) bool {
+			return c == ',' || c == ' ' || c == '\t'
+		})
+		for _, code := range words {
+			if !lintCode.MatchString(code) {
+				break
+			}
+			if r.suppressions == nil {
+				r.suppressions = make(map[string]map[string]*suppression)
+			}
+			if r.suppressions[file] == nil {
+				r.suppressions[file] = make(map[string]*suppression)
+			}
+			r.suppressions[file][code] = &suppression{line: lineNum}
+		}
+	}
+}
+
+// commentStart returns the index of the marker that starts a line comment
+// in line, or -1 if there is none. open is the string or block comment
+// left open by the previous line, or nil, and is updated for the next
+// line.
+func (s commentSyntax) commentStart(line string, open **delimited) int {
+	for i := 0; i < len(line); i++ {
+		if d := *open; d != nil {
+			if d.escapes && line[i] == '\\' {
+				i++
+			} else if strings.HasPrefix(line[i:], d.close) {
+				i += len(d.close) - 1
+				*open = nil
+			}
+			continue
+		}
+		if strings.HasPrefix(line[i:], s.marker) {
+			return i
+		}
+		for j := range s.delimited {
+			if d := &s.delimited[j]; strings.HasPrefix(line[i:], d.open) {
+				*open = d
+				i += len(d.open) - 1
+				break
+			}
+		}
+	}
+
+	if *open != nil && !(*open).multiline {
+		*open = nil
+	}
+	return -1
+}
+
+// reportUnusedSuppressions adds an info finding for every suppressed code
+// that never fired, so stale directives get cleaned up.
+func (r *Result) reportUnusedSuppressions() {
+	var files []string
+	for file := range r.suppressions {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		var codes []string
+		for code, sup := range r.suppressions[file] {
+			if !sup.used {
+				codes = append(codes, code)
+			}
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			r.Add(LevelInfo, file, r.suppressions[file][code].line, "L002",
+				fmt.Sprintf("Unused suppression of %s. Remove it from the tctl:disable comment", code))
+		}
+	}
+}
+
+// lintConfig is the format of lint.yaml and .tctl-lint.yaml:
+//
+//	rules:
+//	  T002: error
+//	  T004: off
+type lintConfig struct {
+	Rules map[string]string `yaml:"rules"`
+}
+
+// newResult creates a Result that applies the global lint config and the
+// nearest project lint config above path.
+func newResult(path string) *Result {
+	result := &Result{severities: make(map[string]Level)}
+	result.loadSeverities(filepath.Join(config.ConfigDir(), config.LintFile))
+	if projectConfig := findProjectConfig(path); projectConfig != "" {
+		result.loadSeverities(projectConfig)
+	}
+	return result
+}
+
+// loadSeverities merges rule severities from a lint config file.
+// Missing files are ignored; invalid entries are reported as findings.
+func (r *Result) loadSeverities(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	var cfg lintConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		r.Add(LevelWarning, path, 0, "L001", fmt.Sprintf("Invalid lint config: %v", err))
+		return
+	}
+
+	for code, value := range cfg.Rules {
+		level := Level(strings.ToLower(value))
+		switch level {
+		case LevelError, LevelWarning, LevelInfo, LevelOff:
+			r.severities[code] = level
+		default:
+			r.Add(LevelWarning, path, 0, "L001",
+				fmt.Sprintf("Unknown severity '%s' for %s. Use error, warning, info, or off", value, code))
+		}
+	}
+}
+
+// findProjectConfig returns the nearest .tctl-lint.yaml in path's
+// directory or any parent, or "" if there is none.
+func findProjectConfig(path string) string {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	for {
+		candidate := filepath.Join(dir, ProjectConfigFile)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
 // LintProject lints the entire project.
 func LintProject(root string) *Result {
-	result := &Result{}
+	result := newResult(root)
+	var linted []*lintedTool
 
 	toolsDir := filepath.Join(root, "tools")
 	stateFile := filepath.Join(root, "state.yaml")
@@ -78,8 +344,10 @@ func LintProject(root string) *Result {
 			if strings.HasPrefix(info.Name(), "_") {
 				return nil
 			}
-			if filepath.Ext(path) == ".py" {
-				lintToolFile(path, root, result)
+			if scanner.GetScanner(path) != nil {
+				if lt := lintToolFile(path, root, result); lt != nil {
+					linted = append(linted, lt)
+				}
 			}
 			return nil
 		})
@@ -87,85 +355,117 @@ func LintProject(root string) *Result {
 		result.Add(LevelWarning, "project", 0, "P000", "No tools/ directory found")
 	}
 
+	lintConstraints(linted, result)
+	lintRelated(linted, result)
+	lintPipRequires(linted, result)
+	lintOutputCollisions(linted, result)
+
 	// Lint state.yaml
 	if _, err := os.Stat(stateFile); err == nil {
 		lintStateFile(stateFile, root, result)
 	}
 
+	result.reportUnusedSuppressions()
+
 	return result
 }
 
-func lintToolFile(path, root string, result *Result) {
+// lintedTool is a successfully parsed tool and the path findings about it
+// are reported under.
+type lintedTool struct {
+	tool *tool.Tool
+	file string
+}
+
+func lintToolFile(path, root string, result *Result) *lintedTool {
 	relPath, _ := filepath.Rel(root, path)
 	if relPath == "" {
 		relPath = filepath.Base(path)
 	}
+	result.loadSuppressions(path, relPath)
 
-	s := scanner.GetScanner(path)
-	if s == nil {
-		return
+	if scanner.GetScanner(path) == nil {
+		return nil
 	}
 
-	tool, err := s.Scan(path)
+	tool, err := scanner.ScanFile(path)
 	if err != nil {
 		result.Add(LevelError, relPath, 0, "P000", fmt.Sprintf("Could not parse: %v", err))
-		return
+		return nil
 	}
 
 	if tool == nil {
-		result.Add(LevelError, relPath, 1, "D001", "Module missing docstring")
-		return
+		if isTypeScript(path) {
+			result.Add(LevelError, relPath, 1, "D001", "Missing JSDoc comment with an @tool tag")
+		} else {
+			result.Add(LevelError, relPath, 1, "D001", "Module missing docstring")
+		}
+		return nil
 	}
 
 	// T001: Missing @tool tag
 	if tool.Name == "" {
-		result.Add(LevelError, relPath, 1, "T001", "Missing @tool tag in docstring")
-		return
+		if isTypeScript(path) {
+			result.Add(LevelError, relPath, 1, "T001", "Missing @tool tag in JSDoc comment")
+		} else {
+			result.Add(LevelError, relPath, 1, "T001", "Missing @tool tag in docstring")
+		}
+		return nil
 	}
 
 	// T002: Missing @provides
 	if len(tool.Provides) == 0 {
-		result.Add(LevelWarning, relPath, 0, "T002",
+		result.Add(LevelWarning, relPath, tool.DocEnd, "T002",
 			fmt.Sprintf("%s: Missing @provides tag", tool.Name))
 	}
 
 	// T003: Missing @capability
 	if len(tool.Capabilities) == 0 {
-		result.Add(LevelWarning, relPath, 0, "T003",
+		result.Add(LevelWarning, relPath, tool.DocEnd, "T003",
 			fmt.Sprintf("%s: Missing @capability tags (at least one recommended)", tool.Name))
 	}
 
 	// T004: Missing @keywords
 	if len(tool.Keywords) == 0 {
-		result.Add(LevelWarning, relPath, 0, "T004",
+		result.Add(LevelWarning, relPath, tool.DocEnd, "T004",
 			fmt.Sprintf("%s: Missing @keywords tag (reduces discoverability)", tool.Name))
 	}
 
 	// T005: Missing @output when @provides exists
 	if len(tool.Provides) > 0 && tool.Output == "" {
-		result.Add(LevelWarning, relPath, 0, "T005",
+		result.Add(LevelWarning, relPath, tool.DocEnd, "T005",
 			fmt.Sprintf("%s: Has @provides but no @output path", tool.Name))
 	}
 
 	// T006: Missing @boundary (info only)
 	if len(tool.Boundaries) == 0 {
-		result.Add(LevelInfo, relPath, 0, "T006",
+		result.Add(LevelInfo, relPath, tool.DocEnd, "T006",
 			fmt.Sprintf("%s: No @boundary tags (helps LLM know what tool doesn't do)", tool.Name))
 	}
 
 	// T007: Invalid @freshness
 	validFreshness := map[string]bool{"daily": true, "weekly": true, "monthly": true, "manual": true}
 	if !validFreshness[tool.Freshness] {
-		result.Add(LevelError, relPath, 0, "T007",
+		result.Add(LevelError, relPath, tool.TagLine("@freshness"), "T007",
 			fmt.Sprintf("%s: Invalid @freshness '%s'. Must be one of: daily, weekly, monthly, manual",
 				tool.Name, tool.Freshness))
 	}
 
 	// T010: Missing @example
 	if len(tool.Examples) == 0 {
-		result.Add(LevelInfo, relPath, 0, "T010",
+		result.Add(LevelInfo, relPath, tool.DocEnd, "T010",
 			fmt.Sprintf("%s: No @example provided", tool.Name))
 	}
+
+	lintInterface(tool, relPath, tool.Name+": ", result)
+
+	// T014: Deprecated without pointing to a replacement
+	if tool.Deprecated && tool.DeprecatedReason == "" {
+		result.Add(LevelInfo, relPath, tool.TagLine("@deprecated"), "T014",
+			fmt.Sprintf("%s: @deprecated without a reason (name the replacement tool)", tool.Name))
+	}
+
+	return &lintedTool{tool: tool, file: relPath}
 }
 
 func lintStateFile(path, root string, result *Result) {
@@ -174,6 +474,54 @@ func lintStateFile(path, root string, result *Result) {
 	// - Check for circular references
 }
 
+// ValidArgTypes are the argument types an @interface line may declare.
+var ValidArgTypes = map[string]bool{
+	"string": true,
+	"int":    true,
+	"float":  true,
+	"bool":   true,
+	"file":   true,
+	"path":   true,
+	"dir":    true,
+	"choice": true,
+	"list":   true,
+	"date":   true,
+}
+
+// argTypeAliases maps spellings from other languages to the valid type
+// to suggest instead.
+var argTypeAliases = map[string]string{
+	"str":       "string",
+	"integer":   "int",
+	"number":    "float",
+	"double":    "float",
+	"boolean":   "bool",
+	"directory": "dir",
+	"enum":      "choice",
+	"array":     "list",
+}
+
+// closestArgType returns the valid argument type nearest to typ, or "" if
+// neither a valid type nor an alias is within two edits.
+func closestArgType(typ string) string {
+	candidates := make(map[string]string)
+	for valid := range ValidArgTypes {
+		candidates[valid] = valid
+	}
+	for alias, valid := range argTypeAliases {
+		candidates[alias] = valid
+	}
+
+	best, bestName, bestDist := "", "", 3
+	for name, valid := range candidates {
+		d := util.EditDistance(strings.ToLower(typ), name)
+		if d < bestDist || d == bestDist && name < bestName {
+			best, bestName, bestDist = valid, name, d
+		}
+	}
+	return best
+}
+
 // Directories to skip when scanning for tools
 var skipDirs = map[string]bool{
 	".venv":        true,
@@ -209,7 +557,8 @@ func shouldSkipDir(name string) bool {
 // Unlike LintProject, this works on any path and reports what's needed
 // to make files tctl-compatible.
 func LintPath(path string) *Result {
-	result := &Result{}
+	result := newResult(path)
+	var linted []*lintedTool
 
 	info, err := os.Stat(path)
 	if err != nil {
@@ -232,52 +581,68 @@ func LintPath(path string) *Result {
 			if strings.HasPrefix(info.Name(), "_") || strings.HasPrefix(info.Name(), ".") {
 				return nil
 			}
-			if filepath.Ext(p) == ".py" {
-				lintFileForCompatibility(p, path, result)
+			if scanner.GetScanner(p) != nil {
+				if lt := lintFileForCompatibility(p, path, result); lt != nil {
+					linted = append(linted, lt)
+				}
 			}
 			return nil
 		})
 	} else {
-		lintFileForCompatibility(path, filepath.Dir(path), result)
+		if lt := lintFileForCompatibility(path, filepath.Dir(path), result); lt != nil {
+			linted = append(linted, lt)
+		}
 	}
 
+	lintConstraints(linted, result)
+	lintRelated(linted, result)
+	lintPipRequires(linted, result)
+	lintOutputCollisions(linted, result)
+	result.reportUnusedSuppressions()
+
 	return result
 }
 
 // lintFileForCompatibility checks a file for tctl compatibility,
 // including files that have no tctl metadata at all.
-func lintFileForCompatibility(path, root string, result *Result) {
+func lintFileForCompatibility(path, root string, result *Result) *lintedTool {
 	// Use absolute path so LLMs can locate the file
 	displayPath, err := filepath.Abs(path)
 	if err != nil {
 		displayPath = path
 	}
+	result.loadSuppressions(path, displayPath)
 
-	// Check if file has a docstring at all
-	hasDocstring, docstringContent := checkPythonDocstring(path)
+	// Check if file has a docstring at all. A sidecar can stand in for it.
+	hasDocstring, docstringContent := checkDocstring(path)
+	hasSidecar := scanner.HasSidecar(path)
 
-	if !hasDocstring {
-		result.Add(LevelError, displayPath, 1, "D001",
-			"No module-level docstring. Add a triple-quoted docstring at the top of the file with @tool <name> tag.")
-		return
+	if !hasDocstring && !hasSidecar {
+		if isTypeScript(path) {
+			result.Add(LevelError, displayPath, 1, "D001",
+				"No doc comment with an @tool tag. Add a /** */ comment with an @tool <name> line above the tool's entry point.")
+		} else {
+			result.Add(LevelError, displayPath, 1, "D001",
+				"No module-level docstring. Add a triple-quoted docstring at the top of the file with @tool <name> tag.")
+		}
+		return nil
 	}
 
 	// Check for @tool tag
-	if !strings.Contains(docstringContent, "@tool ") {
+	if !strings.Contains(docstringContent, "@tool ") && !hasSidecar {
 		result.Add(LevelError, displayPath, 1, "T001",
 			"Docstring exists but missing @tool tag. Add '@tool <tool-name>' line inside the docstring.")
 	}
 
 	// Now try to parse as a tool
-	s := scanner.GetScanner(path)
-	if s == nil {
-		return
+	if scanner.GetScanner(path) == nil {
+		return nil
 	}
 
-	tool, err := s.Scan(path)
+	tool, err := scanner.ScanFile(path)
 	if err != nil {
 		result.Add(LevelError, displayPath, 0, "P001", fmt.Sprintf("Parse error: %v", err))
-		return
+		return nil
 	}
 
 	if tool == nil {
@@ -285,49 +650,234 @@ func lintFileForCompatibility(path, root string, result *Result) {
 		if strings.Contains(docstringContent, "@tool ") {
 			result.Add(LevelError, displayPath, 1, "T001",
 				"@tool tag found but could not parse. Check format: @tool <name>")
+		} else if hasSidecar {
+			result.Add(LevelError, displayPath, 1, "T001",
+				fmt.Sprintf("Neither the docstring nor %s names the tool. Add: name: <tool-name>", filepath.Base(scanner.SidecarPath(path))))
 		}
-		return
+		return nil
 	}
 
 	// Tool parsed successfully, check for recommended fields
 	if len(tool.Provides) == 0 {
-		result.Add(LevelWarning, displayPath, 0, "T002",
+		result.Add(LevelWarning, displayPath, tool.DocEnd, "T002",
 			fmt.Sprintf("Missing @provides tag. Add: @provides <artifact-name>"))
 	}
 
 	if tool.Output == "" {
-		result.Add(LevelWarning, displayPath, 0, "T005",
+		result.Add(LevelWarning, displayPath, tool.DocEnd, "T005",
 			"Missing @output tag. Add: @output <path-or-description>")
 	}
 
 	if len(tool.Keywords) == 0 {
-		result.Add(LevelInfo, displayPath, 0, "T004",
+		result.Add(LevelInfo, displayPath, tool.DocEnd, "T004",
 			"Missing @keywords tag (improves discoverability). Add: @keywords <word1>, <word2>")
 	}
 
 	if tool.Description == "" {
-		result.Add(LevelInfo, displayPath, 0, "T008",
+		result.Add(LevelInfo, displayPath, tool.DocEnd, "T008",
 			"Missing description. Add a description line after the tool name in the docstring.")
 	}
 
 	// Only flag missing @interface if the docstring doesn't contain @interface at all
 	// (tools with "no arguments" documentation are valid)
 	if len(tool.Interface) == 0 && !strings.Contains(docstringContent, "@interface") {
-		result.Add(LevelInfo, displayPath, 0, "T009",
+		result.Add(LevelInfo, displayPath, tool.DocEnd, "T009",
 			"No @interface block. Consider documenting CLI arguments if the tool accepts any.")
 	}
 
 	// Warn if tool has CLI arguments but no @example
 	if len(tool.Interface) > 0 && len(tool.Examples) == 0 {
-		result.Add(LevelWarning, displayPath, 0, "T010",
+		result.Add(LevelWarning, displayPath, tool.DocEnd, "T010",
 			"Tool has CLI arguments but no @example. Add: @example <command-line-example>")
 	}
 
+	lintInterface(tool, displayPath, "", result)
+
+	// Deprecated tools should say what to use instead
+	if tool.Deprecated && tool.DeprecatedReason == "" {
+		result.Add(LevelInfo, displayPath, tool.TagLine("@deprecated"), "T014",
+			"Tool is @deprecated without a reason. Point to the replacement: @deprecated use <other-tool> instead")
+	}
+
 	// Info if tool has @requires - remind about dependencies
 	if len(tool.Requires) > 0 && len(tool.Examples) == 0 {
-		result.Add(LevelInfo, displayPath, 0, "T011",
+		result.Add(LevelInfo, displayPath, tool.TagLine("@requires"), "T011",
 			fmt.Sprintf("Tool requires '%s'. Consider adding @example showing the full workflow.", strings.Join(tool.Requires, ", ")))
 	}
+
+	return &lintedTool{tool: tool, file: displayPath}
+}
+
+// lintInterface checks the tool's @interface arguments. prefix is
+// prepended to each message (e.g. the tool name).
+func lintInterface(t *tool.Tool, file, prefix string, result *Result) {
+	for _, arg := range t.InterfaceArgs() {
+		// T013: Type that isn't a known argument type. Untyped
+		// arguments are strings, as in @interface.
+		if arg.Type != "" && !ValidArgTypes[arg.Type] {
+			msg := fmt.Sprintf("%s%s has unknown type '%s'", prefix, arg.Name, arg.Type)
+			if s := closestArgType(arg.Type); s != "" {
+				msg += fmt.Sprintf(" (did you mean '%s'?)", s)
+			}
+			result.Add(LevelWarning, file, t.TagLine("@interface"), "T013", msg)
+		}
+
+		// T015: Default that isn't one of the declared choices
+		if arg.Default != "" && !arg.AllowsValue(arg.Default) {
+			result.Add(LevelError, file, t.TagLine("@interface"), "T015",
+				fmt.Sprintf("%s%s default '%s' is not one of its choices (%s)",
+					prefix, arg.Name, arg.Default, strings.Join(arg.Choices, "|")))
+		}
+	}
+}
+
+// lintConstraints checks versioned @requires entries (e.g. prices>=1.2)
+// against the providers found among the linted tools.
+func lintConstraints(linted []*lintedTool, result *Result) {
+	providers := make(map[string]*tool.Tool)
+	for _, lt := range linted {
+		for _, p := range lt.tool.Provides {
+			providers[p] = lt.tool
+		}
+	}
+
+	for _, lt := range linted {
+		for _, c := range lt.tool.Constraints {
+			provider := providers[c.Data]
+			// T012: Version constraint against a provider without @version
+			if provider != nil && provider.Version == "" {
+				result.Add(LevelWarning, lt.file, lt.tool.TagLine("@requires"), "T012",
+					fmt.Sprintf("%s: @requires %s but provider %s declares no @version", lt.tool.Name, c, provider.Name))
+			}
+		}
+	}
+}
+
+// lintRelated checks that every @see names a known tool: one of the linted
+// tools or a tool in a registered source.
+func lintRelated(linted []*lintedTool, result *Result) {
+	known := make(map[string]bool)
+	for _, lt := range linted {
+		known[lt.tool.Name] = true
+	}
+
+	var registry *tool.Registry
+	for _, lt := range linted {
+		for _, name := range lt.tool.Related {
+			if known[name] {
+				continue
+			}
+			if registry == nil {
+				registry = registeredTools()
+			}
+			if registry.Get(name) != nil {
+				continue
+			}
+			// T016: @see references an unknown tool
+			result.Add(LevelWarning, lt.file, lt.tool.TagLine("@see"), "T016",
+				fmt.Sprintf("%s: @see %s does not match any known tool", lt.tool.Name, name))
+		}
+	}
+}
+
+// lintPipRequires looks for Python tools whose @requires names something
+// no tool provides, which is usually a package that belongs in @pip.
+func lintPipRequires(linted []*lintedTool, result *Result) {
+	provided := make(map[string]bool)
+	for _, lt := range linted {
+		for _, p := range lt.tool.Provides {
+			provided[p] = true
+		}
+	}
+
+	var registry *tool.Registry
+	for _, lt := range linted {
+		if lt.tool.Language != "python" {
+			continue
+		}
+		for _, req := range lt.tool.Requires {
+			if provided[req] || lt.tool.DeclaresPip(req) {
+				continue
+			}
+			if registry == nil {
+				registry = registeredTools()
+			}
+			// A provider, even an ambiguous one, means req is data
+			if t, err := registry.FindByProvides(req); t != nil || err != nil {
+				continue
+			}
+			// T017: @requires entry that no tool provides and @pip doesn't declare
+			result.Add(LevelInfo, lt.file, lt.tool.TagLine("@requires"), "T017",
+				fmt.Sprintf("%s: no tool provides '%s'. If it is a Python package, declare it with @pip %s",
+					lt.tool.Name, req, req))
+		}
+	}
+}
+
+// lintOutputCollisions reports tools that write to the same resolved
+// @output path and would overwrite each other's data.
+func lintOutputCollisions(linted []*lintedTool, result *Result) {
+	files := make(map[*tool.Tool]string)
+	var tools []*tool.Tool
+	for _, lt := range linted {
+		files[lt.tool] = lt.file
+		tools = append(tools, lt.tool)
+	}
+
+	collisions := tool.OutputCollisions(tools, "")
+	var paths []string
+	for path := range collisions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		writers := collisions[path]
+		for _, t := range writers {
+			var others []string
+			for _, other := range writers {
+				if other != t {
+					others = append(others, other.Name)
+				}
+			}
+			// T018: Another tool writes the same @output
+			result.Add(LevelWarning, files[t], t.TagLine("@output"), "T018",
+				fmt.Sprintf("%s: @output %s is also written by %s", t.Name, path, strings.Join(others, ", ")))
+		}
+	}
+}
+
+// registeredTools scans the registered sources. Errors yield an empty
+// registry so lint still works without any configuration.
+func registeredTools() *tool.Registry {
+	empty := tool.NewRegistry()
+	cfg, err := config.Load()
+	if err != nil {
+		return empty
+	}
+	registry, err := scanner.ScanDirectories(cfg.SourcePaths())
+	if err != nil {
+		return empty
+	}
+	return registry
+}
+
+// checkDocstring reports whether the tool file at path has the comment
+// its scanner reads metadata from, and returns that comment: a Python
+// module docstring, or a TypeScript /** */ doc comment with an @tool tag.
+func checkDocstring(path string) (bool, string) {
+	if isTypeScript(path) {
+		content, ok := scanner.TypeScriptDocComment(path)
+		return ok, content
+	}
+	return checkPythonDocstring(path)
+}
+
+// isTypeScript reports whether path is a file the TypeScript scanner
+// handles.
+func isTypeScript(path string) bool {
+	s := scanner.GetScanner(path)
+	return s != nil && s.Language() == "typescript"
 }
 
 // checkPythonDocstring checks if a Python file has a module-level docstring.
@@ -338,25 +888,25 @@ func checkPythonDocstring(path string) (bool, string) {
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	lineScanner := bufio.NewScanner(file)
 	var lines []string
 	inDocstring := false
 	docstringDelim := ""
+	lineNum := 0
 
-	for scanner.Scan() {
-		line := scanner.Text()
+	for lineScanner.Scan() {
+		lineNum++
+		line := lineScanner.Text()
 		trimmed := strings.TrimSpace(line)
 
-		// Skip shebang and comments at top
-		if strings.HasPrefix(trimmed, "#") {
-			continue
-		}
-
 		if !inDocstring {
-			if strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, `'''`) {
+			// Skip shebang, encoding, and lint directive lines
+			if scanner.IsPythonHeaderLine(lineNum, line) {
+				continue
+			}
+			if delim, rest, ok := scanner.PythonDocstringStart(trimmed); ok {
 				inDocstring = true
-				docstringDelim = trimmed[:3]
-				rest := trimmed[3:]
+				docstringDelim = delim
 				if strings.Contains(rest, docstringDelim) {
 					// Single-line docstring
 					return true, strings.TrimSuffix(rest, docstringDelim)
@@ -364,7 +914,7 @@ func checkPythonDocstring(path string) (bool, string) {
 				lines = append(lines, rest)
 				continue
 			}
-			// Non-empty, non-comment line before docstring = no docstring
+			// Code or a comment before the docstring = no docstring
 			if trimmed != "" {
 				return false, ""
 			}
@@ -424,8 +974,14 @@ func FormatResultsForLLM(result *Result, path string) string {
 	}
 
 	sb.WriteString("## Required Docstring Format\n\n")
-	sb.WriteString("Each Python tool file must have a triple-quoted docstring at the very top of the file (after shebang/imports).\n")
+	sb.WriteString("Each Python tool file must have a triple-quoted docstring at the very top of the file (only a shebang, an encoding line, or a `# tctl:disable` comment may come before it).\n")
 	sb.WriteString("The docstring must contain an `@tool <name>` line. Other tags are optional but recommended.\n\n")
+	sb.WriteString("**Supported tags:**\n\n")
+	for _, tag := range scanner.GetScannerByLanguage("python").Tags() {
+		usage := strings.TrimSpace(tag.Name + " " + tag.Syntax)
+		sb.WriteString(fmt.Sprintf("- `%s`: %s\n", usage, tag.Description))
+	}
+	sb.WriteString("\n")
 	sb.WriteString("**Example:**\n\n")
 	sb.WriteString("```python\n")
 	sb.WriteString(`#!/usr/bin/env python3
@@ -0,0 +1,137 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+func TestLintInterfaceArgTypes(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want bool // whether T013 fires
+	}{
+		{typ: "string", want: false},
+		{typ: "int", want: false},
+		{typ: "", want: false},
+		{typ: "str", want: true},
+		{typ: "widget", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			tl := &tool.Tool{
+				Name:      "a",
+				Interface: map[string]tool.Arg{"--x": {Name: "--x", Type: tt.typ}},
+			}
+			result := &Result{}
+			lintInterface(tl, "a.py", "", result)
+
+			got := false
+			for _, msg := range result.Warnings {
+				if msg.Code == "T013" {
+					got = true
+				}
+			}
+			if got != tt.want {
+				t.Errorf("T013 for type %q = %v, want %v", tt.typ, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintOutputCollisions(t *testing.T) {
+	linted := []*lintedTool{
+		{tool: &tool.Tool{Name: "a", File: "/src/tools/a.py", Output: "data/prices.csv"}, file: "tools/a.py"},
+		{tool: &tool.Tool{Name: "b", File: "/src/tools/b.py", Output: "data/prices.csv"}, file: "tools/b.py"},
+		{tool: &tool.Tool{Name: "c", File: "/src/tools/c.py", Output: "data/other.csv"}, file: "tools/c.py"},
+	}
+	result := &Result{}
+	lintOutputCollisions(linted, result)
+
+	files := make(map[string]bool)
+	for _, msg := range result.Warnings {
+		if msg.Code == "T018" {
+			files[msg.File] = true
+		}
+	}
+	if len(files) != 2 || !files["tools/a.py"] || !files["tools/b.py"] {
+		t.Errorf("T018 reported for %v, want tools/a.py and tools/b.py", files)
+	}
+}
+
+func TestLoadSuppressions(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string // suppressed codes, sorted
+	}{
+		{"own line", "# tctl:disable T004,T010\n", []string{"T004", "T010"}},
+		{"after code", "x = 1  # tctl:disable T004\n", []string{"T004"}},
+		{"after a string with a hash", "x = \"#\" + y  # tctl:disable T004\n", []string{"T004"}},
+		{"reason after codes", "# tctl:disable T004 legacy tool\n", []string{"T004"}},
+		{"reason after a list", "# tctl:disable T004, T010 - see README\n", []string{"T004", "T010"}},
+		{"in a string", "x = \"# tctl:disable T004\"\n", nil},
+		{"in a single-quoted string", "x = '# tctl:disable T004'\n", nil},
+		{"in a docstring", "\"\"\"\nUsage:\n# tctl:disable T004\n\"\"\"\n", nil},
+		{"after a docstring", "\"\"\"Doc.\n\"\"\"  # tctl:disable T004\n", []string{"T004"}},
+		{"escaped quote", "x = \"\\\" # tctl:disable T004\"\n", nil},
+		{"not a directive", "# see tctl:disable T004\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressedCodes(t, "tool.py", tt.source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suppressed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSuppressionsTypeScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string // suppressed codes, sorted
+	}{
+		{"own line", "// tctl:disable T004,T010\n", []string{"T004", "T010"}},
+		{"after code", "const x = 1; // tctl:disable T004 legacy tool\n", []string{"T004"}},
+		{"after a URL string", "const u = \"https://example.com\"; // tctl:disable T004\n", []string{"T004"}},
+		{"hash comment", "# tctl:disable T004\n", nil},
+		{"in a string", "const x = \"// tctl:disable T004\";\n", nil},
+		{"in a template literal", "const x = `\n// tctl:disable T004\n`;\n", nil},
+		{"in a block comment", "/*\n// tctl:disable T004\n*/\n", nil},
+		{"after a block comment", "/* note */ // tctl:disable T004\n", []string{"T004"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressedCodes(t, "tool.ts", tt.source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suppressed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// suppressedCodes writes source to a file called name and returns the
+// codes its tctl:disable comments suppress, sorted.
+func suppressedCodes(t *testing.T, name, source string) []string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result := &Result{}
+	result.loadSuppressions(path, name)
+
+	var codes []string
+	for code := range result.suppressions[name] {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
@@ -0,0 +1,66 @@
+// Package lock keeps tctl processes from running the same tool at the
+// same time. Each tool has a lock file under the config directory; the
+// lock is an OS file lock, so it is released when the holder finishes or
+// exits for any reason, and a crashed process never leaves a stale lock.
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourname/tctl/internal/config"
+)
+
+// Lock is a held tool lock.
+type Lock struct {
+	file *os.File
+}
+
+// Dir returns the directory that holds the lock files.
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), "locks")
+}
+
+// Path returns the lock file for a tool.
+func Path(toolName string) string {
+	name := strings.NewReplacer("/", "_", "\\", "_").Replace(toolName)
+	return filepath.Join(Dir(), name+".lock")
+}
+
+// Acquire locks the tool, waiting for any other process holding it.
+func Acquire(toolName string) (*Lock, error) {
+	return acquire(toolName, true)
+}
+
+// TryAcquire locks the tool if no other process holds it. It returns a
+// nil Lock and no error if the tool is busy.
+func TryAcquire(toolName string) (*Lock, error) {
+	return acquire(toolName, false)
+}
+
+func acquire(toolName string, wait bool) (*Lock, error) {
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return nil, err
+	}
+
+	// The file is never removed: deleting it could let two processes
+	// lock different files under the same name.
+	f, err := os.OpenFile(Path(toolName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	locked, err := lockFile(f, wait)
+	if err != nil || !locked {
+		f.Close()
+		return nil, err
+	}
+	return &Lock{file: f}, nil
+}
+
+// Release unlocks the tool.
+func (l *Lock) Release() error {
+	unlockFile(l.file)
+	return l.file.Close()
+}
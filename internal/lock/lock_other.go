@@ -0,0 +1,13 @@
+//go:build !unix
+
+package lock
+
+import "os"
+
+// lockFile always succeeds: cross-process locking is only implemented on
+// Unix, so elsewhere tools are not protected from concurrent runs.
+func lockFile(f *os.File, wait bool) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(f *os.File) {}
@@ -0,0 +1,107 @@
+// Package runlog keeps a history of tool runs in the config directory.
+// Logging is best-effort: a failure to record a run never fails the run.
+package runlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yourname/tctl/internal/config"
+	"github.com/yourname/tctl/internal/runner"
+)
+
+// MaxSize is the size in bytes at which the log is rotated.
+// One previous generation is kept as runs.jsonl.1.
+var MaxSize int64 = 1 << 20
+
+// Record is a single logged tool run.
+type Record struct {
+	Time       time.Time `json:"time"`
+	Tool       string    `json:"tool"`
+	Args       []string  `json:"args,omitempty"`
+	ExitCode   int       `json:"exit_code"`
+	DurationMS int64     `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Failed reports whether the run exited non-zero or could not start.
+func (r Record) Failed() bool {
+	return r.ExitCode != 0 || r.Error != ""
+}
+
+// Duration returns the run's wall-clock duration.
+func (r Record) Duration() time.Duration {
+	return time.Duration(r.DurationMS) * time.Millisecond
+}
+
+// Path returns the location of the run log.
+func Path() string {
+	return filepath.Join(config.ConfigDir(), config.RunLogFile)
+}
+
+// Append records a finished run. Errors are ignored.
+func Append(toolName string, args []string, res runner.RunResult) {
+	rec := Record{
+		Time:       res.Started,
+		Tool:       toolName,
+		Args:       args,
+		ExitCode:   res.ExitCode,
+		DurationMS: res.Duration.Milliseconds(),
+	}
+	if res.Error != nil {
+		rec.Error = res.Error.Error()
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+
+	if config.EnsureConfigDir() != nil {
+		return
+	}
+
+	path := Path()
+	if info, err := os.Stat(path); err == nil && info.Size() >= MaxSize {
+		os.Rename(path, path+".1")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append(data, '\n'))
+}
+
+// Read returns all logged runs, oldest first, including the rotated
+// generation. Malformed lines are skipped.
+func Read() ([]Record, error) {
+	var records []Record
+	for _, path := range []string{Path() + ".1", Path()} {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var rec Record
+			if json.Unmarshal(scanner.Bytes(), &rec) == nil {
+				records = append(records, rec)
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
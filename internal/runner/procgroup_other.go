@@ -0,0 +1,25 @@
+//go:build !unix
+
+package runner
+
+import (
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+// startInProcessGroup has cancelling cmd kill its process tree. Without
+// Unix process groups, Windows uses taskkill /T; elsewhere only the tool's
+// own process is killed.
+func startInProcessGroup(cmd *exec.Cmd) {
+	if runtime.GOOS != "windows" {
+		return
+	}
+	cmd.Cancel = func() error {
+		pid := strconv.Itoa(cmd.Process.Pid)
+		if err := exec.Command("taskkill", "/T", "/F", "/PID", pid).Run(); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+}
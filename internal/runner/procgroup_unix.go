@@ -0,0 +1,19 @@
+//go:build unix
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// startInProcessGroup makes cmd the leader of a new process group and has
+// cancelling it signal the whole group, so processes the tool spawned are
+// killed along with it.
+func startInProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		// A negative PID signals every process in the group
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
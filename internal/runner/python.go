@@ -1,9 +1,12 @@
 package runner
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/yourname/tctl/pkg/tool"
 )
@@ -27,15 +30,121 @@ func (r *PythonRunner) CanRun(t *tool.Tool) bool {
 	return t.Language == "python" || filepath.Ext(t.File) == ".py"
 }
 
-func (r *PythonRunner) Run(t *tool.Tool, args []string) (int, error) {
+func (r *PythonRunner) Command(t *tool.Tool, args []string) ([]string, error) {
+	if t.Entrypoint != "" {
+		command := r.PythonCommand()
+		if command == nil {
+			return nil, &PythonNotFoundError{}
+		}
+		return append(append(command, "-c", entrypointScript(t)), args...), nil
+	}
+
 	pythonPath := r.findPython()
 	if pythonPath == "" {
-		return 1, &PythonNotFoundError{}
+		return nil, &PythonNotFoundError{}
 	}
 
 	// Build command: python /path/to/tool.py args...
-	cmdArgs := append([]string{t.File}, args...)
-	return execCommand(pythonPath, cmdArgs...)
+	return append([]string{pythonPath, t.File}, args...), nil
+}
+
+func (r *PythonRunner) Run(t *tool.Tool, args []string, opts ExecOptions) (int, error) {
+	command, err := r.Command(t, args)
+	if err != nil {
+		return 1, err
+	}
+	return execCommandWith(command[0], command[1:], opts)
+}
+
+// entrypointScript returns Python code that runs a console script's
+// entry point as its installed script would: named after the script in
+// sys.argv[0] and exiting with the function's return value. The project
+// directory and its src/ come first on sys.path, so the package doesn't
+// need to be installed.
+func entrypointScript(t *tool.Tool) string {
+	module, function, _ := strings.Cut(t.Entrypoint, ":")
+	root := filepath.Dir(t.File)
+
+	// Go's quoted strings are valid Python string literals
+	return fmt.Sprintf(`import importlib, sys
+sys.argv[0] = %s
+sys.path[:0] = [%s, %s]
+target = importlib.import_module(%s)
+for name in %s.split("."):
+    target = getattr(target, name)
+sys.exit(target())`,
+		strconv.Quote(t.Name), strconv.Quote(root), strconv.Quote(filepath.Join(root, "src")),
+		strconv.Quote(module), strconv.Quote(function))
+}
+
+// CheckRuntime verifies the tool's @runtime python requirements (and
+// @min-python) against the interpreter that would run it.
+func (r *PythonRunner) CheckRuntime(t *tool.Tool) error {
+	reqs := t.RuntimeRequirements("python")
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	command := r.PythonCommand()
+	if command == nil {
+		return &PythonNotFoundError{}
+	}
+	return checkRuntime(t, reqs, command[0], append(command[1:], "--version")...)
+}
+
+// PythonCommand returns the command that starts the interpreter tools are
+// run with, e.g. ["/usr/bin/python3"] or ["/usr/bin/uv", "run", "python"].
+// Returns nil if no interpreter is found.
+func (r *PythonRunner) PythonCommand() []string {
+	pythonPath := r.findPython()
+	if pythonPath == "" {
+		return nil
+	}
+	if filepath.Base(pythonPath) == "uv" {
+		return []string{pythonPath, "run", "python"}
+	}
+	return []string{pythonPath}
+}
+
+// Version returns the version of the interpreter tools are run with,
+// such as "3.11.4".
+func (r *PythonRunner) Version() (string, error) {
+	command := r.PythonCommand()
+	if command == nil {
+		return "", &PythonNotFoundError{}
+	}
+	return interpreterVersion(command[0], append(command[1:], "--version")...)
+}
+
+// PipInstallCommand returns the command that installs the given pip
+// requirement specifiers for the interpreter tools are run with. uv is
+// used when available, otherwise the interpreter's own pip.
+func (r *PythonRunner) PipInstallCommand(specs []string) ([]string, error) {
+	command := r.PythonCommand()
+	if command == nil {
+		return nil, &PythonNotFoundError{}
+	}
+
+	var install []string
+	switch {
+	case filepath.Base(command[0]) == "uv":
+		// "uv run python": install into the project's environment
+		install = []string{command[0], "pip", "install"}
+	case r.uvPath() != "":
+		install = []string{r.uvPath(), "pip", "install", "--python", command[0]}
+	default:
+		install = []string{command[0], "-m", "pip", "install"}
+	}
+	return append(install, specs...), nil
+}
+
+// uvPath returns the path of uv on PATH, or "".
+func (r *PythonRunner) uvPath() string {
+	path, err := exec.LookPath("uv")
+	if err != nil {
+		return ""
+	}
+	return path
 }
 
 // findPython locates the Python interpreter.
@@ -67,16 +176,16 @@ func (r *PythonRunner) findPython() string {
 }
 
 // RunWithUV runs a Python tool using uv if available.
-func (r *PythonRunner) RunWithUV(t *tool.Tool, args []string) (int, error) {
+func (r *PythonRunner) RunWithUV(t *tool.Tool, args []string, opts ExecOptions) (int, error) {
 	uvPath, err := exec.LookPath("uv")
 	if err != nil {
 		// Fall back to regular Python
-		return r.Run(t, args)
+		return r.Run(t, args, opts)
 	}
 
 	// uv run python /path/to/tool.py args...
 	cmdArgs := append([]string{"run", "python", t.File}, args...)
-	return execCommand(uvPath, cmdArgs...)
+	return execCommandWith(uvPath, cmdArgs, opts)
 }
 
 // PythonNotFoundError is returned when Python is not found.
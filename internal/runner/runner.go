@@ -3,9 +3,16 @@
 package runner
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/yourname/tctl/internal/term"
 	"github.com/yourname/tctl/pkg/tool"
 )
 
@@ -17,15 +24,81 @@ type Runner interface {
 	// CanRun returns true if this runner can execute the given tool.
 	CanRun(t *tool.Tool) bool
 
+	// Command returns the command line that runs the tool with the given
+	// arguments, starting with the resolved interpreter.
+	Command(t *tool.Tool, args []string) ([]string, error)
+
 	// Run executes a tool with the given arguments.
 	// Returns the exit code.
-	Run(t *tool.Tool, args []string) (int, error)
+	Run(t *tool.Tool, args []string, opts ExecOptions) (int, error)
+}
+
+// RuntimeChecker is implemented by runners that can verify a tool's
+// @runtime requirements (e.g. a minimum interpreter version) before
+// running it.
+type RuntimeChecker interface {
+	CheckRuntime(t *tool.Tool) error
+}
+
+// ExecOptions controls how a tool's process is started and connected to
+// its caller. Zero values fall back to the current process: its terminal,
+// working directory, and environment.
+type ExecOptions struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Dir is the working directory; empty means the current one.
+	Dir string
+
+	// Env holds KEY=value pairs set on top of the inherited environment.
+	Env []string
+
+	// CleanEnv starts the process with only PATH from the current
+	// environment, plus Env, instead of inheriting everything. It keeps
+	// stray credentials and settings away from tools; it is not a
+	// security boundary.
+	CleanEnv bool
+
+	// Context, if set, kills the process when it is done.
+	Context context.Context
+
+	// Timeout, if positive, kills the process and everything it started
+	// once it has run that long, and makes the run fail with a
+	// *TimeoutError. The tool runs in its own process group (on Unix) so
+	// that its children can be killed too. Without Stdin, a tool with a
+	// timeout gets an empty stdin if tctl's stdin is a terminal.
+	Timeout time.Duration
+
+	// Trace, if set, is called at each step of starting and waiting for
+	// the process (see TraceEvent).
+	Trace func(event TraceEvent, detail string)
+}
+
+// TraceEvent names a step in a tool process's lifecycle.
+type TraceEvent string
+
+const (
+	TraceInterpreter TraceEvent = "interpreter resolved"
+	TraceDir         TraceEvent = "cwd set"
+	TraceEnv         TraceEvent = "env built"
+	TraceStarted     TraceEvent = "process started"
+	TraceExited      TraceEvent = "process exited"
+)
+
+// trace reports event to opts.Trace, if set.
+func (opts ExecOptions) trace(event TraceEvent, format string, args ...interface{}) {
+	if opts.Trace != nil {
+		opts.Trace(event, fmt.Sprintf(format, args...))
+	}
 }
 
 // RunResult contains the result of running a tool.
 type RunResult struct {
 	ExitCode int
 	Error    error
+	Started  time.Time
+	Duration time.Duration
 }
 
 // registry of all available runners
@@ -58,11 +131,76 @@ func GetRunnerByLanguage(lang string) Runner {
 
 // Run executes a tool with the given arguments using the appropriate runner.
 func Run(t *tool.Tool, args []string) (int, error) {
+	return RunWith(t, args, ExecOptions{})
+}
+
+// RunWith is like Run but lets the caller redirect the tool's output.
+func RunWith(t *tool.Tool, args []string, opts ExecOptions) (int, error) {
 	runner := GetRunner(t)
 	if runner == nil {
 		return 1, &UnsupportedLanguageError{Language: t.Language}
 	}
-	return runner.Run(t, args)
+	if err := CheckCommands(t); err != nil {
+		return 1, err
+	}
+	if err := CheckEnv(t, opts); err != nil {
+		return 1, err
+	}
+	if checker, ok := runner.(RuntimeChecker); ok {
+		if err := checker.CheckRuntime(t); err != nil {
+			return 1, err
+		}
+	}
+	exitCode, err := runner.Run(t, args, opts)
+	if timeout, ok := err.(*TimeoutError); ok {
+		timeout.Tool = t.Name
+	}
+	return exitCode, err
+}
+
+// Execute runs a tool like RunWith and records when it started and how
+// long it took.
+func Execute(t *tool.Tool, args []string, opts ExecOptions) RunResult {
+	started := time.Now()
+	exitCode, err := RunWith(t, args, opts)
+	return RunResult{
+		ExitCode: exitCode,
+		Error:    err,
+		Started:  started,
+		Duration: time.Since(started),
+	}
+}
+
+// Resolution describes how a tool would be executed.
+type Resolution struct {
+	Runner  string   // language of the runner that matched
+	Command []string // interpreter followed by its arguments
+	Dir     string   // working directory the tool runs in
+	Env     []string // variables set on top of the inherited environment
+}
+
+// Resolve reports how t would be run with args, without running it.
+func Resolve(t *tool.Tool, args []string) (*Resolution, error) {
+	runner := GetRunner(t)
+	if runner == nil {
+		return nil, &UnsupportedLanguageError{Language: t.Language}
+	}
+
+	command, err := runner.Command(t, args)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resolution{
+		Runner:  runner.Language(),
+		Command: command,
+		Dir:     dir,
+	}, nil
 }
 
 // UnsupportedLanguageError is returned when no runner exists for a language.
@@ -74,21 +212,241 @@ func (e *UnsupportedLanguageError) Error() string {
 	return "unsupported language: " + e.Language
 }
 
-// execCommand is a helper for running external commands.
-// It connects stdin/stdout/stderr to the current terminal.
-func execCommand(name string, args ...string) (int, error) {
-	cmd := exec.Command(name, args...)
+// MissingCommandError is returned when a program named in a tool's
+// @requires-cmd is not on PATH.
+type MissingCommandError struct {
+	Tool    string
+	Command string
+}
+
+func (e *MissingCommandError) Error() string {
+	return "missing required command: " + e.Command
+}
+
+// CheckCommands verifies that every program in t's @requires-cmd can be
+// found on PATH.
+func CheckCommands(t *tool.Tool) error {
+	for _, name := range t.RequiresCmd {
+		if _, err := exec.LookPath(name); err != nil {
+			return &MissingCommandError{Tool: t.Name, Command: name}
+		}
+	}
+	return nil
+}
+
+// MissingEnvError is returned when variables named in a tool's
+// @requires-env are unset or empty.
+type MissingEnvError struct {
+	Tool      string
+	Variables []string
+}
+
+func (e *MissingEnvError) Error() string {
+	return "missing required environment variables: " + strings.Join(e.Variables, ", ")
+}
+
+// CheckEnv verifies that every variable in t's @requires-env is set to a
+// non-empty value in the environment opts gives the tool: the current one,
+// unless opts.CleanEnv drops it, plus opts.Env.
+func CheckEnv(t *tool.Tool, opts ExecOptions) error {
+	set := make(map[string]bool)
+	if !opts.CleanEnv {
+		for _, kv := range os.Environ() {
+			if key, value, _ := strings.Cut(kv, "="); value != "" {
+				set[key] = true
+			}
+		}
+	}
+	for _, kv := range opts.Env {
+		key, value, _ := strings.Cut(kv, "=")
+		set[key] = value != ""
+	}
+
+	var missing []string
+	for _, name := range t.RequiresEnv {
+		if !set[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingEnvError{Tool: t.Name, Variables: missing}
+	}
+	return nil
+}
+
+// TimeoutError is returned when a tool is killed for running longer than
+// ExecOptions.Timeout.
+type TimeoutError struct {
+	Tool    string
+	Elapsed time.Duration
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s (limit %s)", e.Tool, e.Elapsed.Round(time.Millisecond), e.Timeout)
+}
+
+// RuntimeVersionError is returned when an interpreter doesn't satisfy a
+// tool's @runtime requirement.
+type RuntimeVersionError struct {
+	Tool        string
+	Requirement tool.Requirement
+	Interpreter string
+	Found       string // version reported by the interpreter
+}
+
+func (e *RuntimeVersionError) Error() string {
+	return fmt.Sprintf("%s requires %s, but %s is version %s",
+		e.Tool, e.Requirement, e.Interpreter, e.Found)
+}
+
+// checkRuntime verifies each requirement in reqs against the version
+// reported by running the interpreter with versionArgs.
+func checkRuntime(t *tool.Tool, reqs []tool.Requirement, interpreter string, versionArgs ...string) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	version, err := interpreterVersion(interpreter, versionArgs...)
+	if err != nil {
+		return fmt.Errorf("checking %s version for %s: %w", reqs[0].Data, t.Name, err)
+	}
+
+	for _, req := range reqs {
+		if !req.SatisfiedBy(version) {
+			return &RuntimeVersionError{
+				Tool:        t.Name,
+				Requirement: req,
+				Interpreter: interpreter,
+				Found:       version,
+			}
+		}
+	}
+	return nil
+}
+
+// versionCache holds interpreter versions already queried by this
+// process, keyed by interpreter path and arguments.
+var (
+	versionCache   = make(map[string]string)
+	versionCacheMu sync.Mutex
+)
+
+// interpreterVersion runs interpreter with args (e.g. "--version") and
+// returns the version number it prints, such as "3.11.4" from
+// "Python 3.11.4". Results are cached for the life of the process.
+func interpreterVersion(interpreter string, args ...string) (string, error) {
+	key := strings.Join(append([]string{interpreter}, args...), " ")
+
+	versionCacheMu.Lock()
+	defer versionCacheMu.Unlock()
+	if v, ok := versionCache[key]; ok {
+		return v, nil
+	}
+
+	out, err := exec.Command(interpreter, args...).CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("%s printed no version", interpreter)
+	}
+
+	// The version is the last word: "Python 3.11.4", "v20.11.0"
+	version := strings.TrimPrefix(fields[len(fields)-1], "v")
+	versionCache[key] = version
+	return version, nil
+}
+
+// execCommandWith runs name with args as described by opts and returns
+// its exit code. Unset streams default to the current terminal.
+// A non-nil error means the command could not be run at all.
+func execCommandWith(name string, args []string, opts ExecOptions) (int, error) {
+	ctx := opts.Context
+	if opts.Timeout > 0 {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var cmd *exec.Cmd
+	if ctx != nil {
+		cmd = exec.CommandContext(ctx, name, args...)
+	} else {
+		cmd = exec.Command(name, args...)
+	}
+	if opts.Timeout > 0 {
+		startInProcessGroup(cmd)
+		// Don't wait forever on output pipes held open by a process
+		// that escaped the group
+		cmd.WaitDelay = 5 * time.Second
+	}
+
 	cmd.Stdin = os.Stdin
+	if opts.Timeout > 0 && term.IsTerminal(os.Stdin) {
+		// The tool's process group isn't the terminal's foreground
+		// group, so reading the terminal would stop it until the
+		// timeout killed it. Give it an empty stdin instead.
+		cmd.Stdin = nil
+	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+	if opts.Stdin != nil {
+		cmd.Stdin = opts.Stdin
+	}
+	if opts.Stdout != nil {
+		cmd.Stdout = opts.Stdout
+	}
+	if opts.Stderr != nil {
+		cmd.Stderr = opts.Stderr
+	}
+
+	opts.trace(TraceInterpreter, "%s", cmd.Path)
+
+	cmd.Dir = opts.Dir
+	if opts.Trace != nil {
+		dir := opts.Dir
+		if dir == "" {
+			dir, _ = os.Getwd()
+		}
+		opts.trace(TraceDir, "%s", dir)
+	}
+
+	inherited := os.Environ()
+	if opts.CleanEnv {
+		inherited = []string{}
+		if path, ok := os.LookupEnv("PATH"); ok {
+			inherited = append(inherited, "PATH="+path)
+		}
+	}
+	if opts.CleanEnv || len(opts.Env) > 0 {
+		cmd.Env = append(inherited, opts.Env...)
+	}
+	opts.trace(TraceEnv, "%d inherited, %d set", len(inherited), len(opts.Env))
 
-	err := cmd.Run()
+	if err := cmd.Start(); err != nil {
+		return 1, err
+	}
+	started := time.Now()
+	opts.trace(TraceStarted, "pid %d", cmd.Process.Pid)
+
+	err := cmd.Wait()
+	if opts.Timeout > 0 && ctx.Err() == context.DeadlineExceeded {
+		opts.trace(TraceExited, "%s", cmd.ProcessState)
+		return 1, &TimeoutError{Elapsed: time.Since(started), Timeout: opts.Timeout}
+	}
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
+			opts.trace(TraceExited, "%s", exitErr.ProcessState)
 			return exitErr.ExitCode(), nil
 		}
 		return 1, err
 	}
+	opts.trace(TraceExited, "%s", cmd.ProcessState)
 	return 0, nil
 }
 
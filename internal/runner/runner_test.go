@@ -0,0 +1,174 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// shell returns the path of sh, skipping the test if there is none.
+func shell(t *testing.T) string {
+	t.Helper()
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh on PATH")
+	}
+	return sh
+}
+
+func TestExecCommandWithStreams(t *testing.T) {
+	sh := shell(t)
+	tests := []struct {
+		name       string
+		script     string
+		stdin      string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{"stdout", "echo out", "", 0, "out\n", ""},
+		{"stderr", "echo err >&2", "", 0, "", "err\n"},
+		{"stdin", "cat", "piped\n", 0, "piped\n", ""},
+		{"exit code", "echo failing >&2; exit 3", "", 3, "", "failing\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code, err := execCommandWith(sh, []string{"-c", tt.script}, ExecOptions{
+				Stdin:  strings.NewReader(tt.stdin),
+				Stdout: &stdout,
+				Stderr: &stderr,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestExecCommandWithDir(t *testing.T) {
+	sh := shell(t)
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if _, err := execCommandWith(sh, []string{"-c", "pwd -P"}, ExecOptions{Dir: dir, Stdout: &stdout}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != dir {
+		t.Errorf("working dir = %q, want %q", got, dir)
+	}
+}
+
+func TestExecCommandWithEnv(t *testing.T) {
+	sh := shell(t)
+	t.Setenv("TCTL_TEST_INHERITED", "inherited")
+
+	tests := []struct {
+		name     string
+		opts     ExecOptions
+		variable string
+		want     string
+	}{
+		{"inherited", ExecOptions{}, "TCTL_TEST_INHERITED", "inherited"},
+		{"set", ExecOptions{Env: []string{"TCTL_TEST_SET=set"}}, "TCTL_TEST_SET", "set"},
+		{"overridden", ExecOptions{Env: []string{"TCTL_TEST_INHERITED=overridden"}}, "TCTL_TEST_INHERITED", "overridden"},
+		{"clean drops inherited", ExecOptions{CleanEnv: true}, "TCTL_TEST_INHERITED", ""},
+		{"clean keeps PATH", ExecOptions{CleanEnv: true}, "PATH", os.Getenv("PATH")},
+		{"clean keeps set", ExecOptions{CleanEnv: true, Env: []string{"TCTL_TEST_SET=set"}}, "TCTL_TEST_SET", "set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := tt.opts
+			opts.Stdout = &stdout
+			script := `printf '%s' "$` + tt.variable + `"`
+			if _, err := execCommandWith(sh, []string{"-c", script}, opts); err != nil {
+				t.Fatal(err)
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("$%s = %q, want %q", tt.variable, stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExecCommandWithTimeout(t *testing.T) {
+	sh := shell(t)
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{"process", "sleep 10"},
+		{"child processes", "sleep 10 & sleep 10 & wait"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			code, err := execCommandWith(sh, []string{"-c", tt.script}, ExecOptions{
+				Stdin:   strings.NewReader(""),
+				Timeout: 100 * time.Millisecond,
+			})
+			var timeout *TimeoutError
+			if !errors.As(err, &timeout) {
+				t.Fatalf("err = %v, want a *TimeoutError", err)
+			}
+			if code == 0 {
+				t.Error("exit code = 0, want non-zero")
+			}
+			if timeout.Timeout != 100*time.Millisecond {
+				t.Errorf("timeout = %s, want 100ms", timeout.Timeout)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("took %s; the process was not killed", elapsed)
+			}
+		})
+	}
+}
+
+func TestExecCommandWithContext(t *testing.T) {
+	sh := shell(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	// Without a timeout there is no process group, so exec to leave
+	// nothing behind for the kill to miss
+	code, err := execCommandWith(sh, []string{"-c", "exec sleep 10"}, ExecOptions{Context: ctx})
+	if err != nil {
+		t.Fatalf("err = %v, want the killed process's exit code", err)
+	}
+	if code == 0 {
+		t.Error("exit code = 0, want non-zero")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("took %s; the process was not killed", elapsed)
+	}
+}
+
+func TestExecCommandWithMissing(t *testing.T) {
+	code, err := execCommandWith(filepath.Join(t.TempDir(), "missing"), nil, ExecOptions{})
+	if err == nil {
+		t.Fatal("missing command: got no error")
+	}
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+}
@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+func init() {
+	Register(&TypeScriptRunner{})
+}
+
+// tsInterpreters are the TypeScript runners tried in order of preference.
+var tsInterpreters = []string{"ts-node", "tsx", "bun"}
+
+// TypeScriptRunner executes TypeScript tools.
+type TypeScriptRunner struct {
+	// InterpreterPath is the path to the TypeScript runner.
+	// If empty, uses a project-local or PATH ts-node, tsx, or bun.
+	InterpreterPath string
+}
+
+func (r *TypeScriptRunner) Language() string {
+	return "typescript"
+}
+
+func (r *TypeScriptRunner) CanRun(t *tool.Tool) bool {
+	ext := filepath.Ext(t.File)
+	return t.Language == "typescript" || ext == ".ts" || ext == ".tsx"
+}
+
+func (r *TypeScriptRunner) Command(t *tool.Tool, args []string) ([]string, error) {
+	interpreter := r.findInterpreter()
+	if interpreter == "" {
+		return nil, &TypeScriptNotFoundError{}
+	}
+
+	// Build command: ts-node /path/to/tool.ts args...
+	return append([]string{interpreter, t.File}, args...), nil
+}
+
+func (r *TypeScriptRunner) Run(t *tool.Tool, args []string, opts ExecOptions) (int, error) {
+	command, err := r.Command(t, args)
+	if err != nil {
+		return 1, err
+	}
+	return execCommandWith(command[0], command[1:], opts)
+}
+
+// CheckRuntime verifies the tool's @runtime requirements on node or bun
+// against the version found on PATH.
+func (r *TypeScriptRunner) CheckRuntime(t *tool.Tool) error {
+	for _, runtime := range []string{"node", "bun"} {
+		reqs := t.RuntimeRequirements(runtime)
+		if len(reqs) == 0 {
+			continue
+		}
+		path, err := exec.LookPath(runtime)
+		if err != nil {
+			return fmt.Errorf("%s requires %s, which is not installed", t.Name, reqs[0])
+		}
+		if err := checkRuntime(t, reqs, path, "--version"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findInterpreter locates a TypeScript runner.
+func (r *TypeScriptRunner) findInterpreter() string {
+	if r.InterpreterPath != "" {
+		return r.InterpreterPath
+	}
+
+	// Prefer the project's own runner (node_modules/.bin)
+	for _, name := range tsInterpreters {
+		local := filepath.Join("node_modules", ".bin", name)
+		if info, err := os.Stat(local); err == nil && !info.IsDir() {
+			if abs, err := filepath.Abs(local); err == nil {
+				return abs
+			}
+		}
+	}
+
+	// Then one from PATH
+	for _, name := range tsInterpreters {
+		if path, err := exec.LookPath(name); err == nil {
+			return path
+		}
+	}
+
+	return ""
+}
+
+// TypeScriptNotFoundError is returned when no TypeScript runner is found.
+type TypeScriptNotFoundError struct{}
+
+func (e *TypeScriptNotFoundError) Error() string {
+	return "no TypeScript runner found (install ts-node, tsx, or bun)"
+}
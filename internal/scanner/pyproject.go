@@ -0,0 +1,107 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// PyprojectFile is a Python project's metadata file. At a source root,
+// the console scripts in its [project.scripts] table are tools too:
+//
+//	[project.scripts]
+//	fetch-prices = "finance.prices:main"
+//
+//	[tool.tctl.scripts.fetch-prices]
+//	provides = ["prices"]
+//	keywords = ["stocks", "quotes"]
+//	output = "data/prices.csv"
+//
+// A script's [tool.tctl.scripts.<name>] table is optional and takes the
+// same keys as a sidecar file.
+const PyprojectFile = "pyproject.toml"
+
+// ScanPyproject returns a tool for each console script declared in the
+// PyprojectFile in dir, sorted by name, or nil if there is no such file.
+// Descriptions and versions default to the [project] table's.
+func ScanPyproject(dir string) ([]*tool.Tool, error) {
+	path := filepath.Join(dir, PyprojectFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	if _, err := toml.Decode(string(data), &doc); err != nil {
+		return nil, err
+	}
+	project := tomlTable(doc, "project")
+	scripts := tomlTable(project, "scripts")
+	settings := tomlTable(tomlTable(tomlTable(doc, "tool"), "tctl"), "scripts")
+
+	for name := range settings {
+		if _, ok := scripts[name]; !ok {
+			return nil, fmt.Errorf("[tool.tctl.scripts.%s] has no matching [project.scripts] entry", name)
+		}
+	}
+
+	names := make([]string, 0, len(scripts))
+	for name := range scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var tools []*tool.Tool
+	for _, name := range names {
+		entrypoint, ok := scripts[name].(string)
+		if !ok {
+			return nil, fmt.Errorf("[project.scripts] %s: entry point must be a string", name)
+		}
+		// Extras ("pkg.cli:main [fast]") only matter to installers
+		entrypoint, _, _ = strings.Cut(entrypoint, "[")
+		entrypoint = strings.TrimSpace(entrypoint)
+		if module, function, ok := strings.Cut(entrypoint, ":"); !ok || module == "" || function == "" {
+			return nil, fmt.Errorf("[project.scripts] %s: entry point %q is not module:function", name, entrypoint)
+		}
+
+		t := &tool.Tool{
+			Name:       name,
+			File:       path,
+			Language:   "python",
+			Entrypoint: entrypoint,
+		}
+		t.Description, _ = project["description"].(string)
+		t.Version, _ = project["version"].(string)
+
+		if table := tomlTable(settings, name); table != nil {
+			// Sidecar keys, so go through the sidecar parser
+			raw, err := yaml.Marshal(table)
+			if err != nil {
+				return nil, err
+			}
+			side, err := parseSidecar(raw)
+			if err != nil {
+				return nil, fmt.Errorf("[tool.tctl.scripts.%s]: %w", name, err)
+			}
+			mergeSidecar(t, side)
+		}
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
+
+// tomlTable returns the table at key in t, or nil if there isn't one.
+func tomlTable(t map[string]interface{}, key string) map[string]interface{} {
+	table, _ := t[key].(map[string]interface{})
+	return table
+}
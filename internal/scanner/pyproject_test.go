@@ -0,0 +1,82 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanPyproject(t *testing.T) {
+	dir := t.TempDir()
+	pyproject := `
+[project]
+name = "finance"
+version = "1.2.0"
+description = "Finance tools"
+released = 1979-05-27T07:32:00Z
+
+[project.scripts]
+fetch-prices = "finance.prices:main [fast]"
+summarize = "finance.summary:run"
+
+[tool.tctl.scripts.fetch-prices]
+provides = ["prices"]
+keywords = ["stocks", "quotes"]
+output = "data/prices.csv"
+`
+	if err := os.WriteFile(filepath.Join(dir, PyprojectFile), []byte(pyproject), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tools, err := ScanPyproject(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tools) != 2 {
+		t.Fatalf("got %d tools, want 2", len(tools))
+	}
+
+	fetch, summarize := tools[0], tools[1]
+	if fetch.Name != "fetch-prices" || summarize.Name != "summarize" {
+		t.Fatalf("names = %s, %s; want fetch-prices, summarize", fetch.Name, summarize.Name)
+	}
+	if fetch.Entrypoint != "finance.prices:main" {
+		t.Errorf("entry point = %q, want finance.prices:main", fetch.Entrypoint)
+	}
+	if fetch.Description != "Finance tools" || fetch.Version != "1.2.0" {
+		t.Errorf("description, version = %q, %q; want the [project] values", fetch.Description, fetch.Version)
+	}
+	if len(fetch.Provides) != 1 || fetch.Provides[0] != "prices" || fetch.Output != "data/prices.csv" {
+		t.Errorf("provides, output = %v, %q; want the [tool.tctl.scripts] values", fetch.Provides, fetch.Output)
+	}
+	if len(summarize.Provides) != 0 {
+		t.Errorf("summarize provides %v, want nothing", summarize.Provides)
+	}
+}
+
+func TestScanPyprojectErrors(t *testing.T) {
+	tests := map[string]string{
+		"invalid TOML":        "[project\n",
+		"orphan settings":     "[project.scripts]\na = \"m:f\"\n[tool.tctl.scripts.b]\noutput = \"x\"\n",
+		"not module:function": "[project.scripts]\na = \"module\"\n",
+		"not a string":        "[project.scripts]\na = 1\n",
+	}
+	for name, pyproject := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, PyprojectFile), []byte(pyproject), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ScanPyproject(dir); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestScanPyprojectMissing(t *testing.T) {
+	tools, err := ScanPyproject(t.TempDir())
+	if tools != nil || err != nil {
+		t.Errorf("ScanPyproject = %v, %v; want nil, nil", tools, err)
+	}
+}
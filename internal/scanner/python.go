@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/yourname/tctl/pkg/tool"
@@ -29,6 +30,10 @@ func (s *PythonScanner) CanScan(path string) bool {
 	return filepath.Ext(path) == ".py"
 }
 
+func (s *PythonScanner) Tags() []TagInfo {
+	return docstringTags
+}
+
 func (s *PythonScanner) Scan(path string) (*tool.Tool, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -37,7 +42,7 @@ func (s *PythonScanner) Scan(path string) (*tool.Tool, error) {
 	defer file.Close()
 
 	// Extract module docstring
-	docstring, err := extractPythonDocstring(file)
+	docstring, start, end, err := extractPythonDocstring(file)
 	if err != nil {
 		return nil, err
 	}
@@ -46,10 +51,12 @@ func (s *PythonScanner) Scan(path string) (*tool.Tool, error) {
 	}
 
 	// Parse @tags from docstring
-	t := parseDocstringTags(docstring)
+	t := parseDocstringTags(docstring, start)
 	if t == nil || t.Name == "" {
 		return nil, nil
 	}
+	t.DocStart = start
+	t.DocEnd = end
 
 	t.File = path
 	t.Language = "python"
@@ -57,40 +64,85 @@ func (s *PythonScanner) Scan(path string) (*tool.Tool, error) {
 	return t, nil
 }
 
-// extractPythonDocstring extracts the module-level docstring from a Python file.
-func extractPythonDocstring(file *os.File) (string, error) {
+// encodingDeclaration matches a PEP 263 source encoding comment,
+// e.g. "# -*- coding: utf-8 -*-".
+var encodingDeclaration = regexp.MustCompile(`^[ \t\f]*#.*?coding[:=][ \t]*[-_.a-zA-Z0-9]+`)
+
+// lintDirective matches a "# tctl:disable" comment, which the linter
+// reads from anywhere in a file.
+var lintDirective = regexp.MustCompile(`^[ \t]*#[ \t]*tctl:disable\b`)
+
+// IsPythonHeaderLine reports whether line (1-based lineNum) may precede a
+// module docstring: a shebang on line 1, an encoding declaration on
+// lines 1-2, or a "# tctl:disable" lint directive. Any other comment ends
+// the search for a docstring.
+func IsPythonHeaderLine(lineNum int, line string) bool {
+	if lineNum == 1 && strings.HasPrefix(line, "#!") {
+		return true
+	}
+	if lintDirective.MatchString(line) {
+		return true
+	}
+	return lineNum <= 2 && encodingDeclaration.MatchString(line)
+}
+
+// stringPrefixes are the valid Python string prefixes, lowercased.
+var stringPrefixes = map[string]bool{
+	"": true, "r": true, "u": true, "b": true, "f": true,
+	"br": true, "rb": true, "fr": true, "rf": true,
+}
+
+// PythonDocstringStart reports whether trimmed opens a triple-quoted
+// string, optionally prefixed with r, b, f, or u in either case
+// (e.g. r"""). It returns the delimiter and the text after it.
+func PythonDocstringStart(trimmed string) (delim, rest string, ok bool) {
+	idx := strings.IndexAny(trimmed, `"'`)
+	if idx == -1 || idx > 2 || !stringPrefixes[strings.ToLower(trimmed[:idx])] {
+		return "", "", false
+	}
+	quoted := trimmed[idx:]
+	if strings.HasPrefix(quoted, `"""`) || strings.HasPrefix(quoted, `'''`) {
+		return quoted[:3], quoted[3:], true
+	}
+	return "", "", false
+}
+
+// extractPythonDocstring extracts the module-level docstring from a Python
+// file, along with the lines of its opening and closing delimiters.
+func extractPythonDocstring(file *os.File) (docstring string, start, end int, err error) {
 	scanner := bufio.NewScanner(file)
 	var lines []string
 	inDocstring := false
 	docstringDelim := ""
+	lineNum := 0
 
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
 		trimmed := strings.TrimSpace(line)
 
-		// Skip shebang and encoding declarations
-		if strings.HasPrefix(trimmed, "#") {
-			continue
-		}
-
 		// Look for docstring start
 		if !inDocstring {
-			if strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, `'''`) {
+			// Skip shebang, encoding, and lint directive lines
+			if IsPythonHeaderLine(lineNum, line) {
+				continue
+			}
+			if delim, rest, ok := PythonDocstringStart(trimmed); ok {
 				inDocstring = true
-				docstringDelim = trimmed[:3]
+				docstringDelim = delim
+				start = lineNum
 
 				// Check for single-line docstring
-				rest := trimmed[3:]
 				if strings.Contains(rest, docstringDelim) {
 					// Single-line docstring
-					return strings.TrimSuffix(rest, docstringDelim), nil
+					return strings.TrimSuffix(rest, docstringDelim), start, start, nil
 				}
 				lines = append(lines, rest)
 				continue
 			}
-			// Not a docstring, probably code
+			// Code or a comment: a module docstring must come first
 			if trimmed != "" {
-				return "", nil
+				return "", 0, 0, nil
 			}
 			continue
 		}
@@ -100,37 +152,46 @@ func extractPythonDocstring(file *os.File) (string, error) {
 			// End of docstring
 			idx := strings.Index(line, docstringDelim)
 			lines = append(lines, line[:idx])
+			end = lineNum
 			break
 		}
 		lines = append(lines, line)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return "", err
+		return "", 0, 0, err
 	}
 
-	return strings.Join(lines, "\n"), nil
+	return strings.Join(lines, "\n"), start, end, nil
 }
 
 // parseDocstringTags parses @tags from a docstring into a Tool struct.
-func parseDocstringTags(docstring string) *tool.Tool {
+// firstLine is the source line the docstring starts on, used to record
+// where each tag appears.
+func parseDocstringTags(docstring string, firstLine int) *tool.Tool {
 	t := &tool.Tool{
 		Freshness: "manual",
 		Interface: make(map[string]tool.Arg),
+		TagLines:  make(map[string]int),
 	}
 
 	lines := strings.Split(docstring, "\n")
 	inInterface := false
+	positionals := 0
 	var descLines []string
 
-	for _, line := range lines {
+	for i, line := range lines {
 		trimmed := strings.TrimSpace(line)
 
 		// Handle @interface block
 		if inInterface {
-			if strings.HasPrefix(trimmed, "--") {
+			if strings.HasPrefix(trimmed, "--") || strings.HasPrefix(trimmed, "<") {
 				arg := parseInterfaceLine(trimmed)
 				if arg != nil {
+					if arg.Positional {
+						positionals++
+						arg.Position = positionals
+					}
 					t.Interface[arg.Name] = *arg
 				}
 				continue
@@ -142,6 +203,13 @@ func parseDocstringTags(docstring string) *tool.Tool {
 			}
 		}
 
+		if strings.HasPrefix(trimmed, "@") && firstLine > 0 {
+			tag := strings.Fields(trimmed)[0]
+			if _, seen := t.TagLines[tag]; !seen {
+				t.TagLines[tag] = firstLine + i
+			}
+		}
+
 		// Parse @tags
 		switch {
 		case strings.HasPrefix(trimmed, "@tool "):
@@ -155,8 +223,41 @@ func parseDocstringTags(docstring string) *tool.Tool {
 			t.Provides = append(t.Provides, items...)
 
 		case strings.HasPrefix(trimmed, "@requires "):
-			items := strings.Fields(trimmed[10:])
-			t.Requires = append(t.Requires, items...)
+			for _, item := range strings.Fields(trimmed[10:]) {
+				req := tool.ParseRequirement(item)
+				t.Requires = append(t.Requires, req.Data)
+				if req.Op != "" {
+					t.Constraints = append(t.Constraints, req)
+				}
+			}
+
+		case strings.HasPrefix(trimmed, "@runtime "):
+			for _, item := range strings.Fields(trimmed[9:]) {
+				t.Runtime = append(t.Runtime, tool.ParseRequirement(item))
+			}
+
+		case strings.HasPrefix(trimmed, "@min-python "):
+			// Shorthand for "@runtime python>=X"
+			t.Runtime = append(t.Runtime, tool.Requirement{
+				Data:    "python",
+				Op:      ">=",
+				Version: strings.TrimSpace(trimmed[12:]),
+			})
+
+		case strings.HasPrefix(trimmed, "@requires-cmd "):
+			// External programs, not data: "@requires-cmd ffmpeg jq"
+			t.RequiresCmd = append(t.RequiresCmd, strings.Fields(trimmed[14:])...)
+
+		case strings.HasPrefix(trimmed, "@requires-env "):
+			// Variables the caller must already have set: "@requires-env DATABASE_URL"
+			t.RequiresEnv = append(t.RequiresEnv, strings.Fields(trimmed[14:])...)
+
+		case strings.HasPrefix(trimmed, "@pip "):
+			// Requirement specifiers as pip takes them: "pandas>=2.0"
+			t.PipRequires = append(t.PipRequires, strings.Fields(trimmed[5:])...)
+
+		case strings.HasPrefix(trimmed, "@output-format "):
+			t.OutputFormat = strings.TrimSpace(trimmed[15:])
 
 		case strings.HasPrefix(trimmed, "@output "):
 			t.Output = strings.TrimSpace(trimmed[8:])
@@ -164,6 +265,12 @@ func parseDocstringTags(docstring string) *tool.Tool {
 		case strings.HasPrefix(trimmed, "@freshness "):
 			t.Freshness = strings.TrimSpace(trimmed[11:])
 
+		case strings.HasPrefix(trimmed, "@concurrency-safe "):
+			// Only an explicit false changes the default
+			if safe, err := strconv.ParseBool(strings.TrimSpace(trimmed[18:])); err == nil {
+				t.ConcurrencyUnsafe = !safe
+			}
+
 		case strings.HasPrefix(trimmed, "@capability "):
 			t.Capabilities = append(t.Capabilities, strings.TrimSpace(trimmed[12:]))
 
@@ -182,11 +289,29 @@ func parseDocstringTags(docstring string) *tool.Tool {
 				}
 			}
 
+		case strings.HasPrefix(trimmed, "@category "):
+			t.Category = strings.TrimSpace(trimmed[10:])
+
+		case strings.HasPrefix(trimmed, "@tag "):
+			// Labels: "experimental" or "key:value", several per line allowed
+			for _, tag := range strings.FieldsFunc(trimmed[5:], func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			}) {
+				t.Tags = append(t.Tags, tag)
+			}
+
 		case strings.HasPrefix(trimmed, "@interface"):
 			inInterface = true
 
 		case strings.HasPrefix(trimmed, "@example "):
-			t.Examples = append(t.Examples, strings.TrimSpace(trimmed[9:]))
+			t.Examples = append(t.Examples, tool.ParseExample(trimmed[9:]))
+
+		case strings.HasPrefix(trimmed, "@see "):
+			t.Related = append(t.Related, strings.Fields(trimmed[5:])...)
+
+		case trimmed == "@deprecated" || strings.HasPrefix(trimmed, "@deprecated "):
+			t.Deprecated = true
+			t.DeprecatedReason = strings.TrimSpace(trimmed[11:])
 
 		case !strings.HasPrefix(trimmed, "@") && trimmed != "":
 			// Collect description lines (before first @tag)
@@ -209,7 +334,8 @@ func parseDocstringTags(docstring string) *tool.Tool {
 // parseInterfaceLine parses a line like: --arg: type, required - Description
 func parseInterfaceLine(line string) *tool.Arg {
 	// Pattern: --name: type, modifiers - description
-	re := regexp.MustCompile(`^(--[\w-]+):\s*(.+)$`)
+	// or, for positional arguments: <name>: type, modifiers - description
+	re := regexp.MustCompile(`^(--[\w-]+|<[\w-]+>):\s*(.+)$`)
 	match := re.FindStringSubmatch(strings.TrimSpace(line))
 	if match == nil {
 		return nil
@@ -232,6 +358,7 @@ func parseInterfaceLine(line string) *tool.Arg {
 	argType := "string"
 	required := false
 	defaultVal := ""
+	var choices []string
 
 	for i, part := range parts {
 		part = strings.TrimSpace(part)
@@ -241,6 +368,15 @@ func parseInterfaceLine(line string) *tool.Arg {
 			required = true
 		} else if strings.HasPrefix(part, "default=") {
 			defaultVal = strings.TrimPrefix(part, "default=")
+		} else if strings.HasPrefix(part, "choices=") {
+			// choices=[a|b|c]
+			list := strings.TrimPrefix(part, "choices=")
+			list = strings.TrimSuffix(strings.TrimPrefix(list, "["), "]")
+			for _, c := range strings.Split(list, "|") {
+				if c = strings.TrimSpace(c); c != "" {
+					choices = append(choices, c)
+				}
+			}
 		}
 	}
 
@@ -249,7 +385,8 @@ func parseInterfaceLine(line string) *tool.Arg {
 		Type:        argType,
 		Required:    required,
 		Default:     defaultVal,
+		Choices:     choices,
+		Positional:  strings.HasPrefix(name, "<"),
 		Description: strings.TrimSpace(description),
 	}
 }
-
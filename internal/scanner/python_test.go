@@ -0,0 +1,190 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+func TestExtractPythonDocstringOrderings(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string // docstring; "" if there is none
+		start  int
+		end    int
+	}{
+		{
+			name:   "docstring first",
+			source: "\"\"\"\n@tool a\n\"\"\"\n",
+			want:   "\n@tool a\n",
+			start:  1,
+			end:    3,
+		},
+		{
+			name:   "after shebang",
+			source: "#!/usr/bin/env python3\n\"\"\"@tool a\"\"\"\n",
+			want:   "@tool a",
+			start:  2,
+			end:    2,
+		},
+		{
+			name:   "after shebang and encoding",
+			source: "#!/usr/bin/env python3\n# -*- coding: utf-8 -*-\n\"\"\"@tool a\"\"\"\n",
+			want:   "@tool a",
+			start:  3,
+			end:    3,
+		},
+		{
+			name:   "encoding on line 3",
+			source: "\n\n# -*- coding: utf-8 -*-\n\"\"\"@tool a\"\"\"\n",
+			want:   "",
+		},
+		{
+			name:   "shebang on line 2",
+			source: "\n#!/usr/bin/env python3\n\"\"\"@tool a\"\"\"\n",
+			want:   "",
+		},
+		{
+			name:   "after lint directive",
+			source: "# tctl:disable T004\n\"\"\"@tool a\"\"\"\n",
+			want:   "@tool a",
+			start:  2,
+			end:    2,
+		},
+		{
+			name:   "after ordinary comment",
+			source: "# Copyright\n\"\"\"@tool a\"\"\"\n",
+			want:   "",
+		},
+		{
+			name:   "comment then code",
+			source: "# helpers\nimport os\n\"\"\"@tool a\"\"\"\n",
+			want:   "",
+		},
+		{
+			name:   "code first",
+			source: "import os\n\"\"\"@tool a\"\"\"\n",
+			want:   "",
+		},
+		{
+			name:   "blank lines first",
+			source: "\n\n'''@tool a'''\n",
+			want:   "@tool a",
+			start:  3,
+			end:    3,
+		},
+		{
+			name:   "raw prefix",
+			source: "r\"\"\"@tool a\"\"\"\n",
+			want:   "@tool a",
+			start:  1,
+			end:    1,
+		},
+		{
+			name:   "uppercase raw prefix",
+			source: "R\"\"\"@tool a\"\"\"\n",
+			want:   "@tool a",
+			start:  1,
+			end:    1,
+		},
+		{
+			name:   "f prefix, single quotes",
+			source: "f'''@tool a'''\n",
+			want:   "@tool a",
+			start:  1,
+			end:    1,
+		},
+		{
+			name:   "raw prefix, content on the opening line",
+			source: "r\"\"\"Fetch prices.\n\n@tool a\n\"\"\"\nimport os\n",
+			want:   "Fetch prices.\n\n@tool a\n",
+			start:  1,
+			end:    4,
+		},
+		{
+			name:   "other quotes don't close",
+			source: "f'''@tool a\nsays \"\"\"hi\"\"\"\n'''\n",
+			want:   "@tool a\nsays \"\"\"hi\"\"\"\n",
+			start:  1,
+			end:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tool.py")
+			if err := os.WriteFile(path, []byte(tt.source), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			got, start, end, err := extractPythonDocstring(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("docstring = %q, want %q", got, tt.want)
+			}
+			if tt.want != "" && (start != tt.start || end != tt.end) {
+				t.Errorf("start, end = %d, %d; want %d, %d", start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestParseDocstringInterface(t *testing.T) {
+	tests := []struct {
+		name      string
+		docstring string
+		want      map[string]tool.Arg
+	}{
+		{
+			name:      "positional",
+			docstring: "@tool a\n@interface\n<path>: string, required - File to read\n",
+			want: map[string]tool.Arg{
+				"<path>": {Name: "<path>", Type: "string", Required: true, Description: "File to read", Positional: true, Position: 1},
+			},
+		},
+		{
+			name:      "choices",
+			docstring: "@tool a\n@interface\n--mode: string, default=full, choices=[full|summary|none] - Report mode\n",
+			want: map[string]tool.Arg{
+				"--mode": {Name: "--mode", Type: "string", Default: "full", Choices: []string{"full", "summary", "none"}, Description: "Report mode"},
+			},
+		},
+		{
+			name: "flags and positionals",
+			docstring: "@tool a\n@interface\n" +
+				"<input>: string, required - File to read\n" +
+				"--symbols: string, required - Ticker symbols\n" +
+				"<output>: string - File to write\n" +
+				"--limit: int, default=10 - Rows to keep\n" +
+				"--format: string, choices=[csv|json]\n" +
+				"@category data\n",
+			want: map[string]tool.Arg{
+				"<input>":   {Name: "<input>", Type: "string", Required: true, Description: "File to read", Positional: true, Position: 1},
+				"--symbols": {Name: "--symbols", Type: "string", Required: true, Description: "Ticker symbols"},
+				"<output>":  {Name: "<output>", Type: "string", Description: "File to write", Positional: true, Position: 2},
+				"--limit":   {Name: "--limit", Type: "int", Default: "10", Description: "Rows to keep"},
+				"--format":  {Name: "--format", Type: "string", Choices: []string{"csv", "json"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDocstringTags(tt.docstring, 1).Interface
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("interface = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
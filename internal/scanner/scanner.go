@@ -3,6 +3,7 @@
 package scanner
 
 import (
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -41,6 +42,12 @@ func shouldSkipDir(name string) bool {
 	return false
 }
 
+// ShouldSkipDir reports whether scans skip directories with this name,
+// such as .git, node_modules, or a virtualenv.
+func ShouldSkipDir(name string) bool {
+	return shouldSkipDir(name)
+}
+
 // Scanner extracts tool metadata from source files.
 // Each language implements this interface.
 type Scanner interface {
@@ -56,11 +63,24 @@ type Scanner interface {
 	// Scan extracts tool metadata from a single file.
 	// Returns nil if the file is not a valid tool.
 	Scan(path string) (*tool.Tool, error)
+
+	// Tags returns the metadata tags this scanner understands and acts on.
+	Tags() []TagInfo
 }
 
 // registry of all available scanners
 var scanners []Scanner
 
+// logger receives a record for every file decision ScanDirectories makes.
+// It discards everything unless SetLogger is called.
+var logger = slog.New(slog.DiscardHandler)
+
+// SetLogger makes ScanDirectories log why each file was skipped, scanned,
+// or rejected.
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
+
 // Register adds a scanner to the registry.
 func Register(s Scanner) {
 	scanners = append(scanners, s)
@@ -106,12 +126,131 @@ func ScanDirectory(dir string) (*tool.Registry, error) {
 }
 
 // ScanDirectories scans multiple directories for tools.
+// Directories are scanned in order, so when two directories define a tool
+// with the same name the later one wins (see tool.Registry.Add). Each
+// directory's SourceConfigFile supplies defaults for its tools. Console
+// scripts in a directory's PyprojectFile are added first, so a tool file
+// of the same name in that directory wins. Files that can't be read or
+// parsed are recorded in the registry's Errors.
 func ScanDirectories(dirs []string) (*tool.Registry, error) {
 	registry := tool.NewRegistry()
 
+	for _, dir := range dirs {
+		onError := func(path string, err error) {
+			registry.Errors = append(registry.Errors, tool.ScanError{File: path, Err: err})
+		}
+
+		source, err := LoadSourceConfig(dir)
+		if err != nil {
+			logger.Warn("source config error", "path", dir, "err", err)
+			onError(filepath.Join(dir, SourceConfigFile), err)
+			source = &SourceConfig{}
+		}
+
+		scripts, err := ScanPyproject(dir)
+		if err != nil {
+			logger.Warn("parse error", "path", filepath.Join(dir, PyprojectFile), "err", err)
+			onError(filepath.Join(dir, PyprojectFile), err)
+		}
+		for _, t := range scripts {
+			logger.Info("scanned", "path", t.File, "tool", t.Name, "entrypoint", t.Entrypoint)
+			source.apply(t)
+			registry.Add(t)
+		}
+
+		walkToolFiles(dir, logger, onError, func(path string, info os.FileInfo) {
+			t, err := ScanFile(path)
+			switch {
+			case err != nil:
+				logger.Warn("parse error", "path", path, "err", err)
+				registry.Errors = append(registry.Errors, tool.ScanError{File: path, Err: err})
+			case t == nil:
+				logger.Info("not a tool", "path", path, "reason", "no docstring with @tool (and no sidecar)")
+			default:
+				logger.Info("scanned", "path", path, "tool", t.Name, "sidecar", HasSidecar(path))
+				source.apply(t)
+				registry.Add(t)
+			}
+		})
+	}
+
+	return registry, nil
+}
+
+// ScanFile extracts a tool from a single file, merging in its sidecar
+// metadata (see SidecarPath) if there is one. Fields set in the sidecar
+// override the docstring; fields it leaves out keep their docstring
+// values, so a sidecar alone is enough for files without a docstring.
+// Returns nil if no scanner handles the file or it doesn't describe a tool.
+func ScanFile(path string) (*tool.Tool, error) {
+	scanner := GetScanner(path)
+	if scanner == nil {
+		return nil, nil
+	}
+
+	t, err := scanner.Scan(path)
+	if err != nil {
+		return nil, err
+	}
+
+	side, err := loadSidecar(path)
+	if err != nil {
+		return nil, err
+	}
+	if side == nil {
+		return t, nil
+	}
+
+	if t == nil {
+		t = &tool.Tool{Language: scanner.Language()}
+	}
+	mergeSidecar(t, side)
+	t.File = path
+
+	if t.Name == "" {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// WalkToolFiles calls fn for every file under dir that a scanner may
+// handle, skipping excluded directories and private files (starting with
+// _ or .). A missing dir is not an error.
+func WalkToolFiles(dir string, fn func(path string, info os.FileInfo)) {
+	walkToolFiles(dir, slog.New(slog.DiscardHandler), nil, fn)
+}
+
+// IsToolFile reports whether WalkToolFiles(root, ...) would pass path to
+// its callback, without walking root. path must be inside root.
+func IsToolFile(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	for _, dir := range parts[:len(parts)-1] {
+		if shouldSkipDir(dir) {
+			return false
+		}
+	}
+	name := parts[len(parts)-1]
+	if len(name) > 0 && (name[0] == '_' || name[0] == '.') {
+		return false
+	}
+	for _, ext := range SupportedExtensions() {
+		if filepath.Ext(name) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// walkToolFiles is WalkToolFiles, logging each file or directory it
+// passes over to log and calling onError for any it can't read.
+func walkToolFiles(dir string, log *slog.Logger, onError func(path string, err error), fn func(path string, info os.FileInfo)) {
 	exts := SupportedExtensions()
 	if len(exts) == 0 {
-		return registry, nil
+		return
 	}
 
 	// Build extension set for quick lookup
@@ -120,54 +259,45 @@ func ScanDirectories(dirs []string) (*tool.Registry, error) {
 		extSet[ext] = true
 	}
 
-	for _, dir := range dirs {
-		// Check directory exists
-		if _, err := os.Stat(dir); os.IsNotExist(err) {
-			continue
-		}
-
-		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return nil
-			}
-
-			// Skip excluded directories
-			if info.IsDir() {
-				if shouldSkipDir(info.Name()) {
-					return filepath.SkipDir
-				}
-				return nil
-			}
-
-			// Skip private files (starting with _ or .)
-			name := info.Name()
-			if len(name) > 0 && (name[0] == '_' || name[0] == '.') {
-				return nil
-			}
+	// Check directory exists
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		log.Warn("source missing", "path", dir)
+		return
+	}
 
-			// Check if file has a supported extension
-			ext := filepath.Ext(path)
-			if !extSet[ext] {
-				return nil
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			log.Warn("unreadable", "path", path, "err", err)
+			if onError != nil {
+				onError(path, err)
 			}
+			return nil
+		}
 
-			scanner := GetScanner(path)
-			if scanner == nil {
-				return nil
+		// Skip excluded directories
+		if info.IsDir() {
+			if shouldSkipDir(info.Name()) {
+				log.Info("skipped", "path", path, "reason", "excluded directory")
+				return filepath.SkipDir
 			}
+			return nil
+		}
 
-			t, err := scanner.Scan(path)
-			if err != nil {
-				return nil
-			}
-			if t != nil {
-				registry.Add(t)
-			}
+		// Skip private files (starting with _ or .)
+		name := info.Name()
+		if len(name) > 0 && (name[0] == '_' || name[0] == '.') {
+			log.Info("skipped", "path", path, "reason", "private file")
+			return nil
+		}
 
+		// Check if file has a supported extension
+		if !extSet[filepath.Ext(path)] {
+			log.Info("skipped", "path", path, "reason", "unsupported extension")
 			return nil
-		})
-	}
+		}
 
-	return registry, nil
+		fn(path, info)
+		return nil
+	})
 }
 
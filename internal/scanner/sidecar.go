@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// SidecarSuffix names the metadata file that can sit next to a tool's
+// source: report.py is described by report.tctl.yaml. Its keys are the
+// Tool struct's YAML names (name, provides, requires, output, ...).
+const SidecarSuffix = ".tctl.yaml"
+
+// SidecarPath returns the sidecar metadata path for a source file.
+func SidecarPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + SidecarSuffix
+}
+
+// HasSidecar reports whether a sidecar metadata file exists for path.
+func HasSidecar(path string) bool {
+	info, err := os.Stat(SidecarPath(path))
+	return err == nil && !info.IsDir()
+}
+
+// sidecar is parsed sidecar metadata. keys records which YAML keys it
+// sets, so that a key set to false, 0 or "" still overrides the docstring.
+type sidecar struct {
+	tool *tool.Tool
+	keys map[string]bool
+}
+
+// sourceKeys are always taken from the source file, never from a sidecar.
+var sourceKeys = []string{"file", "entrypoint", "tag_lines", "doc_start", "doc_end"}
+
+// loadSidecar reads the sidecar for path. It returns nil if there is none.
+func loadSidecar(path string) (*sidecar, error) {
+	data, err := os.ReadFile(SidecarPath(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	side, err := parseSidecar(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", SidecarPath(path), err)
+	}
+	return side, nil
+}
+
+// parseSidecar parses sidecar metadata. It is also the format of a
+// script's table in pyproject.toml (see ScanPyproject).
+func parseSidecar(data []byte) (*sidecar, error) {
+	var side tool.Tool
+	if err := yaml.Unmarshal(data, &side); err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(raw))
+	for key := range raw {
+		keys[key] = true
+	}
+
+	// Versioned requirements are written as in @requires: "prices>=1.2"
+	requires := side.Requires
+	side.Requires = nil
+	for _, item := range requires {
+		req := tool.ParseRequirement(item)
+		side.Requires = append(side.Requires, req.Data)
+		if req.Op != "" {
+			side.Constraints = append(side.Constraints, req)
+		}
+	}
+
+	// Interface arguments are keyed by name, as in @interface, and are
+	// strings unless they say otherwise
+	for name, arg := range side.Interface {
+		arg.Name = name
+		arg.Positional = strings.HasPrefix(name, "<")
+		if arg.Type == "" {
+			arg.Type = "string"
+		}
+		side.Interface[name] = arg
+	}
+
+	// The file, entry point, and source positions always come from the
+	// source file
+	for _, key := range sourceKeys {
+		delete(keys, key)
+	}
+
+	// Constraints belong to the requires list they were parsed from
+	if keys["requires"] {
+		keys["constraints"] = true
+	}
+
+	return &sidecar{tool: &side, keys: keys}, nil
+}
+
+// mergeSidecar copies every field the sidecar sets over t, zero values
+// included. Fields the sidecar leaves out keep their docstring values.
+func mergeSidecar(t *tool.Tool, side *sidecar) {
+	dst := reflect.ValueOf(t).Elem()
+	src := reflect.ValueOf(side.tool).Elem()
+	typ := src.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		key, _, _ := strings.Cut(typ.Field(i).Tag.Get("yaml"), ",")
+		if side.keys[key] {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sidecarTool = `"""
+Fetch stock prices.
+
+@tool fetch-prices
+@version 1.0.0
+@provides prices
+@requires symbols>=2.0
+@output data/prices.csv
+@output-format csv
+@concurrency-safe false
+@category finance
+"""
+`
+
+// writeTool writes a tool with the given source and sidecar ("" for no
+// sidecar) and returns its path.
+func writeTool(t *testing.T, source, side string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fetch.py")
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if side != "" {
+		if err := os.WriteFile(SidecarPath(path), []byte(side), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestSidecarOverrides(t *testing.T) {
+	side := `
+concurrency_unsafe: false
+version: ""
+output_format: ""
+provides: [quotes]
+`
+	path := writeTool(t, sidecarTool, side)
+	got, err := ScanFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil {
+		t.Fatal("no tool")
+	}
+
+	if got.ConcurrencyUnsafe {
+		t.Error("concurrency_unsafe: false did not override @concurrency-safe false")
+	}
+	if got.Version != "" || got.OutputFormat != "" {
+		t.Errorf("version, output format = %q, %q; want them cleared", got.Version, got.OutputFormat)
+	}
+	if len(got.Provides) != 1 || got.Provides[0] != "quotes" {
+		t.Errorf("provides = %v, want [quotes]", got.Provides)
+	}
+
+	// Keys the sidecar leaves out keep their docstring values
+	if got.Name != "fetch-prices" || got.Output != "data/prices.csv" || got.Category != "finance" {
+		t.Errorf("name, output, category = %q, %q, %q; want the docstring values", got.Name, got.Output, got.Category)
+	}
+	if len(got.Constraints) != 1 || got.Constraints[0].Data != "symbols" {
+		t.Errorf("constraints = %v, want the docstring's symbols>=2.0", got.Constraints)
+	}
+	if got.File != path {
+		t.Errorf("file = %q, want %q", got.File, path)
+	}
+}
+
+func TestSidecarRequires(t *testing.T) {
+	tests := []struct {
+		name        string
+		side        string
+		requires    []string
+		constraints int
+	}{
+		{"replaced", "requires: [symbols, calendar>=1.1]\n", []string{"symbols", "calendar"}, 1},
+		{"unversioned", "requires: [symbols]\n", []string{"symbols"}, 0},
+		{"cleared", "requires: []\n", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScanFile(writeTool(t, sidecarTool, tt.side))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Requires) != len(tt.requires) {
+				t.Fatalf("requires = %v, want %v", got.Requires, tt.requires)
+			}
+			for i := range tt.requires {
+				if got.Requires[i] != tt.requires[i] {
+					t.Errorf("requires = %v, want %v", got.Requires, tt.requires)
+				}
+			}
+			if len(got.Constraints) != tt.constraints {
+				t.Errorf("constraints = %v, want %d", got.Constraints, tt.constraints)
+			}
+		})
+	}
+}
+
+func TestSidecarSourceKeys(t *testing.T) {
+	side := "file: elsewhere.py\nentrypoint: other:main\ndoc_start: 40\n"
+	path := writeTool(t, sidecarTool, side)
+	got, err := ScanFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.File != path || got.Entrypoint != "" || got.DocStart != 1 {
+		t.Errorf("file, entry point, doc start = %q, %q, %d; want the source's", got.File, got.Entrypoint, got.DocStart)
+	}
+}
+
+func TestSidecarOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		side string
+		want string // tool name; "" for no tool
+	}{
+		{"named", "name: report\nprovides: [summary]\nconcurrency_unsafe: true\n", "report"},
+		{"unnamed", "provides: [summary]\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScanFile(writeTool(t, "print('hello')\n", tt.side))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("got tool %q, want none", got.Name)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("no tool, want %q", tt.want)
+			}
+			if got.Name != tt.want || got.Language != "python" || !got.ConcurrencyUnsafe {
+				t.Errorf("name, language, unsafe = %q, %q, %v; want %q, python, true", got.Name, got.Language, got.ConcurrencyUnsafe, tt.want)
+			}
+		})
+	}
+}
+
+func TestSidecarInvalid(t *testing.T) {
+	path := writeTool(t, sidecarTool, "provides: [unclosed\n")
+	if _, err := ScanFile(path); err == nil {
+		t.Error("invalid sidecar: got no error")
+	}
+}
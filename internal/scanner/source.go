@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourname/tctl/internal/freshness"
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+// SourceConfigFile holds defaults for the tools in one source. It lives
+// at the source root.
+const SourceConfigFile = ".tctl.yaml"
+
+// SourceConfig is the format of SourceConfigFile:
+//
+//	default_freshness: daily
+//	default_language: typescript
+//	output_base: ../data
+//
+// Tags in a tool always win over these defaults.
+type SourceConfig struct {
+	// DefaultFreshness applies to tools without a @freshness tag.
+	DefaultFreshness string `yaml:"default_freshness,omitempty"`
+
+	// DefaultLanguage is the template language for 'tctl new' in this
+	// source, instead of the default_language setting.
+	DefaultLanguage string `yaml:"default_language,omitempty"`
+
+	// OutputBase is the directory relative @output paths are resolved
+	// against, itself relative to the source root.
+	OutputBase string `yaml:"output_base,omitempty"`
+}
+
+// LoadSourceConfig reads the SourceConfigFile in dir. A missing file gives
+// an empty config.
+func LoadSourceConfig(dir string) (*SourceConfig, error) {
+	var cfg SourceConfig
+	path := filepath.Join(dir, SourceConfigFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	if _, ok := freshness.Thresholds[cfg.DefaultFreshness]; cfg.DefaultFreshness != "" && !ok {
+		return nil, fmt.Errorf("invalid default_freshness '%s' (valid: daily, weekly, monthly, manual)", cfg.DefaultFreshness)
+	}
+	if cfg.OutputBase != "" && !filepath.IsAbs(cfg.OutputBase) {
+		cfg.OutputBase = filepath.Join(dir, cfg.OutputBase)
+	}
+	return &cfg, nil
+}
+
+// apply fills in the defaults t doesn't set itself.
+func (c *SourceConfig) apply(t *tool.Tool) {
+	// The docstring parser already fills in manual for an absent
+	// @freshness; a sidecar-only tool has none at all
+	absent := t.TagLine("@freshness") == 0 && (t.Freshness == "" || t.Freshness == "manual")
+	if c.DefaultFreshness != "" && absent {
+		t.Freshness = c.DefaultFreshness
+	}
+	if c.OutputBase != "" && t.OutputBase == "" {
+		t.OutputBase = c.OutputBase
+	}
+}
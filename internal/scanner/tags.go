@@ -0,0 +1,50 @@
+package scanner
+
+// TagInfo describes a metadata tag that a scanner understands.
+type TagInfo struct {
+	Name        string // the tag itself, e.g. "@provides"
+	Syntax      string // what follows the tag, e.g. "<artifact>..."
+	Description string
+}
+
+// docstringTags are the tags parseDocstringTags reads, in the order they
+// are usually written.
+var docstringTags = []TagInfo{
+	{"@tool", "<name>", "Tool name (kebab-case); required"},
+	{"@version", "<version>", "Semantic version of the tool"},
+	{"@provides", "<artifact>...", "Data this tool produces, optionally namespaced (finance/report)"},
+	{"@requires", "<artifact>[>=version]...", "Data this tool needs, optionally with a minimum provider version"},
+	{"@runtime", "<runtime><op><version>...", "Interpreter version the tool needs, checked before it runs"},
+	{"@min-python", "<version>", "Shorthand for @runtime python>=VERSION"},
+	{"@pip", "<requirement>...", "Python packages the tool imports, as pip requirement specifiers"},
+	{"@requires-cmd", "<program>...", "External programs the tool runs, checked on PATH before it runs"},
+	{"@requires-env", "<variable>...", "Environment variables that must be set, checked before it runs"},
+	{"@output", "<path>", "Output file or directory, relative to the source root"},
+	{"@output-format", "<format>", "Default format of the output file"},
+	{"@freshness", "daily|weekly|monthly|manual", "How long the output stays fresh"},
+	{"@concurrency-safe", "true|false", "Whether the tool may run alongside others in 'tctl get --jobs' (default true)"},
+	{"@capability", "<text>", "Something this tool does (repeatable)"},
+	{"@boundary", "<text>", "Something this tool does NOT do (repeatable)"},
+	{"@keywords", "<word>, ...", "Search terms"},
+	{"@category", "<category>", "Grouping for listings, e.g. ops/logging"},
+	{"@tag", "<label>...", "Exact-match labels for 'tctl list --tag'"},
+	{"@interface", "", "Starts the CLI arguments block, one '--name: type, modifiers - description' per line"},
+	{"@example", "[<label>:] <command>", "Usage example, optionally labeled (repeatable)"},
+	{"@see", "<tool>...", "Related tools, shown by 'tctl show'"},
+	{"@deprecated", "[<reason>]", "Marks the tool as deprecated; it still runs, with a warning"},
+}
+
+// tagsExcept returns docstringTags without the named tags.
+func tagsExcept(names ...string) []TagInfo {
+	skip := make(map[string]bool)
+	for _, name := range names {
+		skip[name] = true
+	}
+	var tags []TagInfo
+	for _, tag := range docstringTags {
+		if !skip[tag.Name] {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,137 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourname/tctl/pkg/tool"
+)
+
+func init() {
+	Register(&TypeScriptScanner{})
+}
+
+// TypeScriptScanner extracts tool metadata from TypeScript doc comments.
+// The metadata lives in the first /** */ block that contains an @tool tag,
+// typically at the top of the file or above the exported entry point.
+type TypeScriptScanner struct{}
+
+func (s *TypeScriptScanner) Language() string {
+	return "typescript"
+}
+
+func (s *TypeScriptScanner) Extensions() []string {
+	return []string{".ts", ".tsx"}
+}
+
+func (s *TypeScriptScanner) CanScan(path string) bool {
+	// Declaration files describe types, never tools
+	if strings.HasSuffix(path, ".d.ts") {
+		return false
+	}
+	ext := filepath.Ext(path)
+	return ext == ".ts" || ext == ".tsx"
+}
+
+// Tags leaves out the Python-only tags, which are parsed but have no
+// effect on a TypeScript tool.
+func (s *TypeScriptScanner) Tags() []TagInfo {
+	return tagsExcept("@pip", "@min-python")
+}
+
+func (s *TypeScriptScanner) Scan(path string) (*tool.Tool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	comment, start, end, err := extractToolDocComment(file)
+	if err != nil {
+		return nil, err
+	}
+	if comment == "" {
+		return nil, nil
+	}
+
+	t := parseDocstringTags(comment, start)
+	if t == nil || t.Name == "" {
+		return nil, nil
+	}
+	t.DocStart = start
+	t.DocEnd = end
+
+	t.File = path
+	t.Language = "typescript"
+
+	return t, nil
+}
+
+// TypeScriptDocComment returns the body of the /** */ comment in the
+// TypeScript file at path that holds the tool's metadata, and whether
+// there is one. The linter uses it as the counterpart of a Python
+// module docstring.
+func TypeScriptDocComment(path string) (string, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	comment, _, _, err := extractToolDocComment(file)
+	if err != nil || comment == "" {
+		return "", false
+	}
+	return comment, true
+}
+
+// extractToolDocComment returns the body of the first /** */ comment that
+// contains an @tool tag, with the leading " * " of each line removed, and
+// the lines the comment starts and ends on.
+func extractToolDocComment(file *os.File) (comment string, start, end int, err error) {
+	scanner := bufio.NewScanner(file)
+	var lines []string
+	inComment := false
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		trimmed := strings.TrimSpace(scanner.Text())
+
+		if !inComment {
+			if !strings.HasPrefix(trimmed, "/**") {
+				continue
+			}
+			inComment = true
+			start = lineNum
+			lines = nil
+			trimmed = strings.TrimPrefix(trimmed, "/**")
+		}
+
+		closed := false
+		if idx := strings.Index(trimmed, "*/"); idx != -1 {
+			trimmed = trimmed[:idx]
+			closed = true
+		}
+
+		// Strip the conventional leading "*" of each comment line
+		trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "*"))
+		lines = append(lines, trimmed)
+
+		if closed {
+			inComment = false
+			body := strings.Join(lines, "\n")
+			if strings.Contains(body, "@tool ") {
+				return body, start, lineNum, nil
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", 0, 0, err
+	}
+
+	return "", 0, 0, nil
+}
@@ -0,0 +1,71 @@
+// Package term colors terminal output. Color is on only when stdout is a
+// terminal and neither NO_COLOR nor --no-color asks for plain output, so
+// piped and machine-readable output never contains escape codes.
+package term
+
+import "os"
+
+const (
+	reset  = "\033[0m"
+	red    = "\033[31m"
+	green  = "\033[32m"
+	yellow = "\033[33m"
+	dim    = "\033[2m"
+)
+
+var enabled = detect()
+
+// detect reports whether stdout is a color-capable terminal.
+func detect() bool {
+	// https://no-color.org: any non-empty value disables color
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(os.Stdout)
+}
+
+// IsTerminal reports whether f is a terminal rather than a file or pipe.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Enabled reports whether output is colored.
+func Enabled() bool {
+	return enabled
+}
+
+// Disable turns color off for the rest of the process.
+func Disable() {
+	enabled = false
+}
+
+// Green colors success: fresh data, passed checks, ✓.
+func Green(s string) string {
+	return paint(green, s)
+}
+
+// Red colors errors and failures.
+func Red(s string) string {
+	return paint(red, s)
+}
+
+// Yellow colors warnings and stale data.
+func Yellow(s string) string {
+	return paint(yellow, s)
+}
+
+// Dim de-emphasizes secondary details.
+func Dim(s string) string {
+	return paint(dim, s)
+}
+
+func paint(color, s string) string {
+	if !enabled || s == "" {
+		return s
+	}
+	return color + s + reset
+}
@@ -0,0 +1,50 @@
+package util
+
+import "sort"
+
+// EditDistance returns the Levenshtein distance between a and b.
+func EditDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// Closest returns the candidates within maxDist edits of s, nearest
+// first and then alphabetically, leaving out s itself.
+func Closest(s string, candidates []string, maxDist int) []string {
+	dist := make(map[string]int)
+	for _, c := range candidates {
+		if _, seen := dist[c]; seen || c == s {
+			continue
+		}
+		if d := EditDistance(s, c); d <= maxDist {
+			dist[c] = d
+		}
+	}
+
+	closest := make([]string, 0, len(dist))
+	for c := range dist {
+		closest = append(closest, c)
+	}
+	sort.Slice(closest, func(i, j int) bool {
+		if dist[closest[i]] != dist[closest[j]] {
+			return dist[closest[i]] < dist[closest[j]]
+		}
+		return closest[i] < closest[j]
+	})
+	return closest
+}
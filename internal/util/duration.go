@@ -0,0 +1,27 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration is time.ParseDuration extended with days ("7d") and weeks
+// ("2w"), which time.ParseDuration doesn't accept.
+func ParseDuration(s string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.ParseFloat(n, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(count * float64(unit)), nil
+		}
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,195 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Matcher decides whether a search term occurs in a piece of text. By
+// default a term matches anywhere in the text, ignoring case, so "id"
+// matches "liquidity". Search commands share one Matcher so their
+// options behave the same everywhere.
+type Matcher struct {
+	caseSensitive bool
+	wholeWord     bool
+	stem          bool
+	words         map[string]*regexp.Regexp // compiled whole-word patterns by term
+}
+
+// wordPattern splits text into words for stemmed matching.
+var wordPattern = regexp.MustCompile(`[A-Za-z0-9]+`)
+
+// NewMatcher returns a Matcher. With caseSensitive, case must match
+// exactly; with wholeWord, a term only matches at word boundaries; with
+// stem, a term also matches any word with the same stem (see Stem), so
+// "parsing" finds "parses".
+func NewMatcher(caseSensitive, wholeWord, stem bool) *Matcher {
+	return &Matcher{
+		caseSensitive: caseSensitive,
+		wholeWord:     wholeWord,
+		stem:          stem,
+		words:         make(map[string]*regexp.Regexp),
+	}
+}
+
+// Contains reports whether term occurs in text.
+func (m *Matcher) Contains(text, term string) bool {
+	if term == "" || text == "" {
+		return false
+	}
+
+	if m.stem && m.containsStem(text, term) {
+		return true
+	}
+
+	if m.wholeWord {
+		re, ok := m.words[term]
+		if !ok {
+			pattern := `\b` + regexp.QuoteMeta(term) + `\b`
+			if !m.caseSensitive {
+				pattern = "(?i)" + pattern
+			}
+			re = regexp.MustCompile(pattern)
+			m.words[term] = re
+		}
+		return re.MatchString(text)
+	}
+
+	if m.caseSensitive {
+		return strings.Contains(text, term)
+	}
+	return strings.Contains(strings.ToLower(text), strings.ToLower(term))
+}
+
+// containsStem reports whether a word in text has the same stem as term.
+// Stems are lowercase, so when case matters the word must also be
+// written like term where the two are spelled alike: "Parsing" doesn't
+// match "parses".
+func (m *Matcher) containsStem(text, term string) bool {
+	stem := Stem(term)
+	for _, word := range wordPattern.FindAllString(text, -1) {
+		if Stem(word) == stem && (!m.caseSensitive || sameCase(word, term)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameCase reports whether a and b have the same case over the prefix
+// they share when case is ignored.
+func sameCase(a, b string) bool {
+	lower := func(c byte) byte {
+		if c >= 'A' && c <= 'Z' {
+			return c + 'a' - 'A'
+		}
+		return c
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if lower(a[i]) != lower(b[i]) {
+			return true // the shared prefix ends here
+		}
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchTerm is a word to search for: either one the user typed, or a
+// synonym of one.
+type SearchTerm struct {
+	Text      string
+	SynonymOf string // the typed word, if Text is a synonym of it
+}
+
+// Score returns weight for a direct match, and one less (but at least 1)
+// for a synonym match.
+func (t SearchTerm) Score(weight int) int {
+	if t.SynonymOf == "" || weight <= 1 {
+		return weight
+	}
+	return weight - 1
+}
+
+// Reason annotates a match reason when the match came from a synonym.
+func (t SearchTerm) Reason(reason string) string {
+	if t.SynonymOf == "" {
+		return reason
+	}
+	return fmt.Sprintf("%s (matched synonym '%s' for '%s')", reason, t.Text, t.SynonymOf)
+}
+
+// ExpandTerms returns words as search terms followed by their synonyms.
+// synonyms maps a word to its synonyms; lookups go both ways and ignore
+// case, and a synonym that is also a typed word is not added twice.
+func ExpandTerms(words []string, synonyms map[string][]string) []SearchTerm {
+	var terms []SearchTerm
+	seen := make(map[string]bool)
+	for _, w := range words {
+		terms = append(terms, SearchTerm{Text: w})
+		seen[strings.ToLower(w)] = true
+	}
+
+	// Sorted so expansions come out in the same order every time
+	keys := make([]string, 0, len(synonyms))
+	for word := range synonyms {
+		keys = append(keys, word)
+	}
+	sort.Strings(keys)
+
+	for _, w := range words {
+		for _, word := range keys {
+			related := append([]string{word}, synonyms[word]...)
+			if !containsFold(related, w) {
+				continue
+			}
+			for _, s := range related {
+				if !seen[strings.ToLower(s)] {
+					seen[strings.ToLower(s)] = true
+					terms = append(terms, SearchTerm{Text: s, SynonymOf: w})
+				}
+			}
+		}
+	}
+	return terms
+}
+
+// SplitPhrases splits search arguments into words and the phrases among
+// them. A phrase is text in double quotes inside an argument, as in
+// '"error parse" logs', or a whole argument with spaces in it, which the
+// shell quoted: "error parse". Phrase words are also returned as words,
+// so each can still match on its own. An unterminated quote runs to the
+// end of its argument.
+func SplitPhrases(args []string) (phrases, words []string) {
+	for _, arg := range args {
+		if !strings.Contains(arg, `"`) {
+			fields := strings.Fields(arg)
+			if len(fields) > 1 {
+				phrases = append(phrases, strings.Join(fields, " "))
+			}
+			words = append(words, fields...)
+			continue
+		}
+
+		// Odd parts were inside quotes
+		for i, part := range strings.Split(arg, `"`) {
+			fields := strings.Fields(part)
+			if i%2 == 1 && len(fields) > 1 {
+				phrases = append(phrases, strings.Join(fields, " "))
+			}
+			words = append(words, fields...)
+		}
+	}
+	return phrases, words
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
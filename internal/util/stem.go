@@ -0,0 +1,68 @@
+package util
+
+import "strings"
+
+// Stem reduces a word to a crude stem by stripping common English
+// inflections, so "parse", "parses", "parsing", and "parsed" all become
+// "pars". Stems are only good for comparing with each other; they are
+// often not words. Stems are lowercase, whatever the case of word.
+func Stem(word string) string {
+	w := strings.ToLower(word)
+	if len(w) <= 3 || invariantWords[w] {
+		return w
+	}
+
+	switch {
+	case strings.HasSuffix(w, "ies") || strings.HasSuffix(w, "ied"):
+		w = w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "ing") && hasVowel(w[:len(w)-3]):
+		w = undouble(w[:len(w)-3])
+	case strings.HasSuffix(w, "ed") && hasVowel(w[:len(w)-2]) && len(w) >= 5:
+		w = undouble(w[:len(w)-2])
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") &&
+		!strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is"):
+		w = w[:len(w)-1]
+	}
+
+	// "parse" and "pars(ing)" should meet
+	if len(w) > 3 && strings.HasSuffix(w, "e") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
+
+// invariantWords end like inflections but aren't: "news" isn't the
+// plural of "new", and "series" isn't the plural of "sery".
+var invariantWords = map[string]bool{
+	"always":  true,
+	"bias":    true,
+	"canvas":  true,
+	"chaos":   true,
+	"lens":    true,
+	"news":    true,
+	"perhaps": true,
+	"series":  true,
+	"species": true,
+	"towards": true,
+}
+
+// hasVowel reports whether s contains a vowel, so stripping "ing" from
+// "string" or "thing" is avoided.
+func hasVowel(s string) bool {
+	return strings.ContainsAny(s, "aeiouy")
+}
+
+// undouble turns a doubled final consonant left by stripping a suffix
+// into a single one ("runn" -> "run"), except for l, s, and z, which
+// double in the base word too ("fall", "pass", "buzz").
+func undouble(w string) string {
+	n := len(w)
+	if n < 2 || w[n-1] != w[n-2] {
+		return w
+	}
+	switch w[n-1] {
+	case 'a', 'e', 'i', 'o', 'u', 'l', 's', 'z':
+		return w
+	}
+	return w[:n-1]
+}
@@ -0,0 +1,73 @@
+package util
+
+import "testing"
+
+func TestStem(t *testing.T) {
+	// Each pair of words should meet at the same stem
+	same := [][2]string{
+		{"parse", "parses"},
+		{"parse", "parsing"},
+		{"parse", "parsed"},
+		{"run", "running"},
+		{"stop", "stopped"},
+		{"query", "queries"},
+		{"query", "queried"},
+		{"price", "prices"},
+		{"report", "reports"},
+		{"report", "reporting"},
+		{"fetch", "fetched"},
+		{"Parse", "parsing"},
+		{"PRICES", "price"},
+	}
+	for _, pair := range same {
+		if a, b := Stem(pair[0]), Stem(pair[1]); a != b {
+			t.Errorf("Stem(%q) = %q, Stem(%q) = %q; want the same stem", pair[0], a, pair[1], b)
+		}
+	}
+
+	// And these should not
+	different := [][2]string{
+		{"news", "new"},
+		{"series", "sery"},
+		{"string", "str"},
+		{"thing", "th"},
+		{"bus", "bu"},
+		{"status", "statu"},
+		{"analysis", "analysi"},
+		{"class", "clas"},
+	}
+	for _, pair := range different {
+		if a, b := Stem(pair[0]), Stem(pair[1]); a == b {
+			t.Errorf("Stem(%q) = Stem(%q) = %q; want different stems", pair[0], pair[1], a)
+		}
+	}
+}
+
+func TestStemUnchanged(t *testing.T) {
+	for _, word := range []string{"csv", "news", "series", "bus", "class", "string", "thing"} {
+		if got := Stem(word); got != word {
+			t.Errorf("Stem(%q) = %q, want it unchanged", word, got)
+		}
+	}
+}
+
+func TestMatcherStemCase(t *testing.T) {
+	tests := []struct {
+		caseSensitive bool
+		text, term    string
+		want          bool
+	}{
+		{false, "Parses CSV files", "parsing", true},
+		{false, "PARSES CSV files", "parsing", true},
+		{true, "parses CSV files", "parsing", true},
+		{true, "Parses CSV files", "parsing", false},
+		{true, "Parses CSV files", "Parsing", true},
+		{false, "Daily news digest", "new", false},
+	}
+	for _, tt := range tests {
+		m := NewMatcher(tt.caseSensitive, true, true)
+		if got := m.Contains(tt.text, tt.term); got != tt.want {
+			t.Errorf("Contains(%q, %q) case-sensitive=%v = %v, want %v", tt.text, tt.term, tt.caseSensitive, got, tt.want)
+		}
+	}
+}
@@ -2,11 +2,13 @@
 package util
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
 
-// StopWords are common words to exclude from keyword extraction.
+// StopWords are the common words excluded from keyword extraction by
+// default. The config package adds and removes words from a copy of them.
 var StopWords = map[string]bool{
 	"a": true, "an": true, "the": true, "is": true, "are": true,
 	"was": true, "were": true, "be": true, "been": true,
@@ -16,14 +18,19 @@ var StopWords = map[string]bool{
 	"its": true, "does": true, "do": true,
 }
 
-// ExtractKeywords extracts meaningful words from text, excluding stop words.
-func ExtractKeywords(text string) []string {
+// ExtractKeywords extracts meaningful words from text, excluding stop
+// words. A nil stopWords means the built-in StopWords.
+func ExtractKeywords(text string, stopWords map[string]bool) []string {
+	if stopWords == nil {
+		stopWords = StopWords
+	}
+
 	re := regexp.MustCompile(`\b[a-zA-Z]{3,}\b`)
 	words := re.FindAllString(strings.ToLower(text), -1)
 
 	var result []string
 	for _, w := range words {
-		if !StopWords[w] {
+		if !stopWords[w] {
 			result = append(result, w)
 		}
 	}
@@ -46,3 +53,60 @@ func Max(a, b int) int {
 	return b
 }
 
+
+// SplitShellWords splits a line into words the way a POSIX shell would,
+// without expansions: whitespace separates words, single quotes keep
+// text literally, double quotes keep spaces but honor \" and \\, and a
+// backslash outside quotes escapes the next character.
+func SplitShellWords(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune // ' or " while inside quotes
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+				word.WriteRune(runes[i])
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
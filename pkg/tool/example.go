@@ -0,0 +1,94 @@
+package tool
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Example is an @example entry: a command line with an optional label,
+// written "@example Basic: tctl run x --out a.csv".
+//
+// An unlabeled example is stored in YAML and JSON as a plain string, as
+// all examples were before labels; a labeled one is stored as
+// {label, command}. Both forms are accepted when decoding.
+type Example struct {
+	Label   string `yaml:"label,omitempty" json:"label,omitempty"`
+	Command string `yaml:"command" json:"command"`
+}
+
+// ParseExample parses an @example entry. Text before the first colon is a
+// label if it is plain words (letters, digits, spaces, "-", "_", and
+// parentheses) and doesn't start with "tctl"; otherwise the whole entry
+// is the command.
+func ParseExample(s string) Example {
+	s = strings.TrimSpace(s)
+	label, command, found := strings.Cut(s, ":")
+	label = strings.TrimSpace(label)
+	if !found || !isExampleLabel(label) || strings.TrimSpace(command) == "" {
+		return Example{Command: s}
+	}
+	return Example{Label: label, Command: strings.TrimSpace(command)}
+}
+
+// isExampleLabel reports whether s can be the label of an @example.
+func isExampleLabel(s string) bool {
+	if s == "" || s == "tctl" || strings.HasPrefix(s, "tctl ") || strings.Contains(s, "--") {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" -_()", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the example in @example syntax.
+func (e Example) String() string {
+	if e.Label == "" {
+		return e.Command
+	}
+	return e.Label + ": " + e.Command
+}
+
+// MarshalYAML writes an unlabeled example as a plain string.
+func (e Example) MarshalYAML() (interface{}, error) {
+	if e.Label == "" {
+		return e.Command, nil
+	}
+	type plain Example
+	return plain(e), nil
+}
+
+// UnmarshalYAML reads an example written as a string or as a mapping.
+func (e *Example) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*e = ParseExample(value.Value)
+		return nil
+	}
+	type plain Example
+	return value.Decode((*plain)(e))
+}
+
+// MarshalJSON writes an unlabeled example as a plain string.
+func (e Example) MarshalJSON() ([]byte, error) {
+	if e.Label == "" {
+		return json.Marshal(e.Command)
+	}
+	type plain Example
+	return json.Marshal(plain(e))
+}
+
+// UnmarshalJSON reads an example written as a string or as an object.
+func (e *Example) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*e = ParseExample(s)
+		return nil
+	}
+	type plain Example
+	return json.Unmarshal(data, (*plain)(e))
+}
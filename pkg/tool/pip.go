@@ -0,0 +1,29 @@
+package tool
+
+import "strings"
+
+// PipPackageName returns the package name from a pip requirement
+// specifier: "pandas[excel]>=2.0" gives "pandas".
+func PipPackageName(spec string) string {
+	if idx := strings.IndexAny(spec, "[=<>!~;@ "); idx != -1 {
+		spec = spec[:idx]
+	}
+	return strings.TrimSpace(spec)
+}
+
+// DeclaresPip reports whether the tool lists the named package in @pip.
+// Names are compared the way pip does: case-insensitively, with "-", "_"
+// and "." treated alike.
+func (t *Tool) DeclaresPip(name string) bool {
+	want := normalizePipName(name)
+	for _, spec := range t.PipRequires {
+		if normalizePipName(PipPackageName(spec)) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizePipName(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
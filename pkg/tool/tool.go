@@ -2,6 +2,15 @@
 // This is language-agnostic - scanners for each language populate these structs.
 package tool
 
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // Tool represents a single tool with its metadata extracted from source.
 type Tool struct {
 	Name         string            `yaml:"name" json:"name"`
@@ -11,41 +20,402 @@ type Tool struct {
 	Description  string            `yaml:"description,omitempty" json:"description,omitempty"`
 	Provides     []string          `yaml:"provides,omitempty" json:"provides,omitempty"`
 	Requires     []string          `yaml:"requires,omitempty" json:"requires,omitempty"`
+	Constraints  []Requirement     `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Runtime      []Requirement     `yaml:"runtime,omitempty" json:"runtime,omitempty"`
+	PipRequires  []string          `yaml:"pip_requires,omitempty" json:"pip_requires,omitempty"`
+	RequiresCmd  []string          `yaml:"requires_cmd,omitempty" json:"requires_cmd,omitempty"`
+	RequiresEnv  []string          `yaml:"requires_env,omitempty" json:"requires_env,omitempty"`
 	Output       string            `yaml:"output,omitempty" json:"output,omitempty"`
+	OutputFormat string            `yaml:"output_format,omitempty" json:"output_format,omitempty"`
 	Freshness    string            `yaml:"freshness,omitempty" json:"freshness,omitempty"`
 	Capabilities []string          `yaml:"capabilities,omitempty" json:"capabilities,omitempty"`
 	Boundaries   []string          `yaml:"boundaries,omitempty" json:"boundaries,omitempty"`
 	Keywords     []string          `yaml:"keywords,omitempty" json:"keywords,omitempty"`
+	Category     string            `yaml:"category,omitempty" json:"category,omitempty"`
+	Tags         []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
 	Interface    map[string]Arg    `yaml:"interface,omitempty" json:"interface,omitempty"`
-	Examples     []string          `yaml:"examples,omitempty" json:"examples,omitempty"`
+	Examples     []Example         `yaml:"examples,omitempty" json:"examples,omitempty"`
+	Related      []string          `yaml:"related,omitempty" json:"related,omitempty"`
+
+	// Deprecated tools still run but warn; DeprecatedReason usually names
+	// the replacement.
+	Deprecated       bool   `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
+	DeprecatedReason string `yaml:"deprecated_reason,omitempty" json:"deprecated_reason,omitempty"`
+
+	// ConcurrencyUnsafe tools, marked "@concurrency-safe false", must not
+	// run at the same time as each other, e.g. because they write to a
+	// shared database. Tools are concurrency-safe by default.
+	ConcurrencyUnsafe bool `yaml:"concurrency_unsafe,omitempty" json:"concurrency_unsafe,omitempty"`
+
+	// Entrypoint, for a console script from a pyproject.toml, is the
+	// "module:function" it runs. File is then the pyproject.toml.
+	Entrypoint string `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty"`
+
+	// OutputBase, if set, is the directory relative @output paths are
+	// resolved against, as given by output_base in the source's .tctl.yaml.
+	OutputBase string `yaml:"output_base,omitempty" json:"output_base,omitempty"`
+
+	// Source positions, 1-based (0 means unknown). TagLines maps each tag
+	// (e.g. "@output") to the line of its first occurrence; DocStart and
+	// DocEnd are the lines of the opening and closing docstring delimiters.
+	TagLines map[string]int `yaml:"tag_lines,omitempty" json:"tag_lines,omitempty"`
+	DocStart int            `yaml:"doc_start,omitempty" json:"doc_start,omitempty"`
+	DocEnd   int            `yaml:"doc_end,omitempty" json:"doc_end,omitempty"`
+}
+
+// OutputPath returns the path of the tool's @output, or "" if it has none.
+// Relative outputs are resolved against OutputBase if it is set, otherwise
+// against the parent of the tool's directory (the source root for tools
+// kept in a tools/ directory).
+func (t *Tool) OutputPath() string {
+	return t.OutputPathIn("")
+}
+
+// OutputPathIn is like OutputPath, but resolves relative outputs against
+// base instead. An empty base means the default.
+func (t *Tool) OutputPathIn(base string) string {
+	if t.Output == "" || filepath.IsAbs(t.Output) {
+		return t.Output
+	}
+	if base == "" {
+		base = t.OutputBase
+	}
+	if base == "" {
+		base = filepath.Join(filepath.Dir(t.File), "..")
+	}
+	return filepath.Join(base, t.Output)
+}
+
+// OutputCollisions groups tools by @output path, resolved against base as
+// in OutputPathIn, and returns the paths declared by more than one tool,
+// each with its tools sorted by name. A glob @output also collides with
+// the paths it matches, since their files would count as its output.
+func OutputCollisions(tools []*Tool, base string) map[string][]*Tool {
+	byPath := make(map[string][]*Tool)
+	for _, t := range tools {
+		if path := t.OutputPathIn(base); path != "" {
+			path = filepath.Clean(path)
+			byPath[path] = append(byPath[path], t)
+		}
+	}
+
+	collisions := make(map[string][]*Tool)
+	for path, writers := range byPath {
+		if strings.ContainsAny(path, "*?[") {
+			writers = append([]*Tool(nil), writers...)
+			for other, otherWriters := range byPath {
+				if matched, _ := filepath.Match(path, other); matched && other != path {
+					writers = append(writers, otherWriters...)
+				}
+			}
+		}
+		if len(writers) < 2 {
+			continue
+		}
+		sort.Slice(writers, func(i, j int) bool {
+			return writers[i].Name < writers[j].Name
+		})
+		collisions[path] = writers
+	}
+	return collisions
+}
+
+// HasTag reports whether the tool has the exact label tag (e.g.
+// "experimental" or "team:data").
+func (t *Tool) HasTag(tag string) bool {
+	for _, tt := range t.Tags {
+		if tt == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// TagLine returns the source line of tag (e.g. "@output"), or 0 if the
+// tag is absent or its position is unknown.
+func (t *Tool) TagLine(tag string) int {
+	return t.TagLines[tag]
+}
+
+// Constraint returns the version constraint declared for a required data
+// name, or nil if the requirement is unversioned.
+func (t *Tool) Constraint(data string) *Requirement {
+	for i := range t.Constraints {
+		if t.Constraints[i].Data == data {
+			return &t.Constraints[i]
+		}
+	}
+	return nil
+}
+
+// RuntimeRequirements returns the tool's @runtime requirements on the
+// named runtime (e.g. "python").
+func (t *Tool) RuntimeRequirements(runtime string) []Requirement {
+	var reqs []Requirement
+	for _, r := range t.Runtime {
+		if r.Data == runtime {
+			reqs = append(reqs, r)
+		}
+	}
+	return reqs
+}
+
+// InterfaceArgs returns the tool's interface arguments: positional
+// arguments first, in order, then flags sorted by name.
+func (t *Tool) InterfaceArgs() []Arg {
+	args := make([]Arg, 0, len(t.Interface))
+	for _, arg := range t.Interface {
+		args = append(args, arg)
+	}
+	sort.Slice(args, func(i, j int) bool {
+		if args[i].Positional != args[j].Positional {
+			return args[i].Positional
+		}
+		if args[i].Positional {
+			return args[i].Position < args[j].Position
+		}
+		return args[i].Name < args[j].Name
+	})
+	return args
+}
+
+// AllowsValue reports whether value is one of the argument's choices.
+// Arguments without choices allow any value.
+func (a Arg) AllowsValue(value string) bool {
+	if len(a.Choices) == 0 {
+		return true
+	}
+	for _, c := range a.Choices {
+		if c == value {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckValue reports why value isn't valid for the argument: not one of
+// its choices, or not parseable as its int, float, bool, or date type.
+// Other types accept any value.
+func (a Arg) CheckValue(value string) error {
+	if !a.AllowsValue(value) {
+		return fmt.Errorf("%s must be one of %s", a.Name, strings.Join(a.Choices, "|"))
+	}
+
+	var err error
+	switch a.Type {
+	case "int":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "float":
+		_, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "date":
+		_, err = time.Parse("2006-01-02", value)
+	}
+	if err != nil {
+		return fmt.Errorf("%s takes %s values, not %q", a.Name, a.Type, value)
+	}
+	return nil
 }
 
 // Arg represents a command-line argument in the tool's interface.
 type Arg struct {
-	Name        string `yaml:"name" json:"name"`
-	Type        string `yaml:"type" json:"type"`
-	Required    bool   `yaml:"required" json:"required"`
-	Default     string `yaml:"default,omitempty" json:"default,omitempty"`
-	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Name        string   `yaml:"name" json:"name"`
+	Type        string   `yaml:"type" json:"type"`
+	Required    bool     `yaml:"required" json:"required"`
+	Default     string   `yaml:"default,omitempty" json:"default,omitempty"`
+	Choices     []string `yaml:"choices,omitempty" json:"choices,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Positional arguments are written "<name>" and passed in Position
+	// order (starting at 1) rather than as flags.
+	Positional bool `yaml:"positional,omitempty" json:"positional,omitempty"`
+	Position   int  `yaml:"position,omitempty" json:"position,omitempty"`
 }
 
 // Registry holds all discovered tools, indexed by name.
 type Registry struct {
 	Tools map[string]*Tool `yaml:"tools" json:"tools"`
+
+	// Shadowed holds tools that were replaced by a later Add with the
+	// same name, keyed by name, in the order they were overridden.
+	Shadowed map[string][]*Tool `yaml:"shadowed,omitempty" json:"shadowed,omitempty"`
+
+	// Errors holds the files that could not be read or parsed while
+	// building the registry, in the order they were found.
+	Errors []ScanError `yaml:"-" json:"-"`
+
+	// providesIndex maps each artifact in Tools' @provides, and the bare
+	// name of each namespaced one, to the tools providing it.
+	providesIndex map[string][]*Tool
+
+	// keywordIndex maps each @keywords entry, as written, to the tools
+	// declaring it.
+	keywordIndex map[string][]*Tool
+
+	// requiresIndex maps each @requires entry, as written, to the tools
+	// declaring it. It answers Dependents, the reverse of providesIndex.
+	requiresIndex map[string][]*Tool
+}
+
+// ScanError records a file that could not be scanned for a tool.
+type ScanError struct {
+	File string
+	Err  error
+}
+
+func (e ScanError) Error() string {
+	return fmt.Sprintf("%s: %v", e.File, e.Err)
 }
 
 // NewRegistry creates an empty tool registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		Tools: make(map[string]*Tool),
+		Tools:         make(map[string]*Tool),
+		Shadowed:      make(map[string][]*Tool),
+		providesIndex: make(map[string][]*Tool),
+		keywordIndex:  make(map[string][]*Tool),
+		requiresIndex: make(map[string][]*Tool),
 	}
 }
 
-// Add adds a tool to the registry.
+// Add adds a tool to the registry, replacing any earlier version of it.
+// If a tool with the same name from a different file already exists, the
+// new tool wins and the old one is recorded in Shadowed. A tool already
+// added from the same file is dropped first, so re-adding a file whose
+// @tool name changed doesn't leave the old name behind. Console scripts
+// share their pyproject.toml, so they only replace tools of the same name.
 func (r *Registry) Add(t *Tool) {
-	if t != nil && t.Name != "" {
-		r.Tools[t.Name] = t
+	if t == nil || t.Name == "" {
+		return
 	}
+	if t.File != "" && t.Entrypoint == "" {
+		r.RemoveByFile(t.File)
+	}
+	if existing := r.Tools[t.Name]; existing != nil {
+		if existing.File != t.File {
+			r.Shadowed[t.Name] = append(r.Shadowed[t.Name], existing)
+		}
+		r.unindex(existing)
+	}
+	r.Tools[t.Name] = t
+	r.index(t)
+}
+
+// Remove drops the tool with the given name, including any versions of it
+// it shadowed. Removing an unknown name does nothing.
+func (r *Registry) Remove(name string) {
+	if t := r.Tools[name]; t != nil {
+		r.unindex(t)
+	}
+	delete(r.Tools, name)
+	delete(r.Shadowed, name)
+}
+
+// RemoveByFile drops the tool defined in path, as when the file is
+// deleted. If it was shadowing another tool of the same name, the most
+// recently shadowed one takes its place.
+func (r *Registry) RemoveByFile(path string) {
+	for name, shadowed := range r.Shadowed {
+		kept := shadowed[:0]
+		for _, t := range shadowed {
+			if t.File != path {
+				kept = append(kept, t)
+			}
+		}
+		r.setShadowed(name, kept)
+	}
+
+	for name, t := range r.Tools {
+		if t.File != path {
+			continue
+		}
+		delete(r.Tools, name)
+		r.unindex(t)
+		if shadowed := r.Shadowed[name]; len(shadowed) > 0 {
+			r.Tools[name] = shadowed[len(shadowed)-1]
+			r.index(r.Tools[name])
+			r.setShadowed(name, shadowed[:len(shadowed)-1])
+		}
+	}
+}
+
+// index adds t to the provides, keyword, and requires indexes.
+func (r *Registry) index(t *Tool) {
+	for _, key := range providesKeys(t) {
+		r.providesIndex[key] = append(r.providesIndex[key], t)
+	}
+	for _, kw := range uniqueStrings(t.Keywords) {
+		r.keywordIndex[kw] = append(r.keywordIndex[kw], t)
+	}
+	for _, req := range uniqueStrings(t.Requires) {
+		r.requiresIndex[req] = append(r.requiresIndex[req], t)
+	}
+}
+
+// unindex removes t from the provides, keyword, and requires indexes.
+func (r *Registry) unindex(t *Tool) {
+	for _, key := range providesKeys(t) {
+		removeFromIndex(r.providesIndex, key, t)
+	}
+	for _, kw := range uniqueStrings(t.Keywords) {
+		removeFromIndex(r.keywordIndex, kw, t)
+	}
+	for _, req := range uniqueStrings(t.Requires) {
+		removeFromIndex(r.requiresIndex, req, t)
+	}
+}
+
+// providesKeys returns the providesIndex keys t is filed under: each
+// artifact it provides and the bare name of each namespaced one.
+func providesKeys(t *Tool) []string {
+	var keys []string
+	for _, p := range t.Provides {
+		keys = append(keys, p)
+		if i := strings.LastIndex(p, "/"); i != -1 {
+			keys = append(keys, p[i+1:])
+		}
+	}
+	return uniqueStrings(keys)
+}
+
+// removeFromIndex drops t from index[key], deleting the key once no
+// tools remain under it.
+func removeFromIndex(index map[string][]*Tool, key string, t *Tool) {
+	var kept []*Tool
+	for _, other := range index[key] {
+		if other != t {
+			kept = append(kept, other)
+		}
+	}
+	if len(kept) == 0 {
+		delete(index, key)
+		return
+	}
+	index[key] = kept
+}
+
+// uniqueStrings returns items without repeats, in their original order.
+func uniqueStrings(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	var unique []string
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// setShadowed records the shadowed versions of name, dropping the entry
+// when there are none.
+func (r *Registry) setShadowed(name string, tools []*Tool) {
+	if len(tools) == 0 {
+		delete(r.Shadowed, name)
+		return
+	}
+	r.Shadowed[name] = tools
 }
 
 // Get retrieves a tool by name.
@@ -53,16 +423,135 @@ func (r *Registry) Get(name string) *Tool {
 	return r.Tools[name]
 }
 
-// FindByProvides finds the tool that provides the given data.
-func (r *Registry) FindByProvides(data string) *Tool {
-	for _, t := range r.Tools {
+// FindByProvides finds the tool that provides the given data. It returns
+// nil and no error when no tool does, and an *AmbiguousProviderError when
+// several do. A tool providing data exactly wins over tools that only
+// match its bare name, so "report" finds the provider of "report" even
+// when "finance/report" exists too.
+func (r *Registry) FindByProvides(data string) (*Tool, error) {
+	providers := r.Providers(data)
+	var exact []*Tool
+	for _, t := range providers {
 		for _, p := range t.Provides {
 			if p == data {
-				return t
+				exact = append(exact, t)
+				break
 			}
 		}
 	}
-	return nil
+	if len(exact) > 0 {
+		providers = exact
+	}
+
+	switch len(providers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return providers[0], nil
+	}
+
+	err := &AmbiguousProviderError{Data: data}
+	for _, t := range providers {
+		for _, p := range t.Provides {
+			if p == data || len(exact) == 0 && ProvidesMatch(p, data) {
+				err.Options = append(err.Options, fmt.Sprintf("%s (%s)", p, t.Name))
+			}
+		}
+	}
+	return nil, err
+}
+
+// Providers returns every tool that provides the given data, sorted by
+// name.
+func (r *Registry) Providers(data string) []*Tool {
+	return sortedByName(r.providesIndex[data])
+}
+
+// WithKeyword returns the tools that list keyword, exactly as written, in
+// their @keywords, sorted by name.
+func (r *Registry) WithKeyword(keyword string) []*Tool {
+	return sortedByName(r.keywordIndex[keyword])
+}
+
+// Keywords returns every distinct @keywords entry in the registry, sorted.
+func (r *Registry) Keywords() []string {
+	keywords := make([]string, 0, len(r.keywordIndex))
+	for kw := range r.keywordIndex {
+		keywords = append(keywords, kw)
+	}
+	sort.Strings(keywords)
+	return keywords
+}
+
+// sortedByName returns a copy of tools sorted by name.
+func sortedByName(tools []*Tool) []*Tool {
+	sorted := append([]*Tool(nil), tools...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// ProvidesMatch reports whether the artifact a tool @provides answers a
+// request for data. Artifacts may be namespaced, as in "finance/report":
+// that matches both "finance/report" and the bare name "report".
+func ProvidesMatch(provides, data string) bool {
+	if provides == data {
+		return true
+	}
+	if strings.Contains(data, "/") {
+		return false
+	}
+	i := strings.LastIndex(provides, "/")
+	return i != -1 && provides[i+1:] == data
+}
+
+// AmbiguousProviderError is returned when several tools provide an
+// artifact, or, if none provides it exactly, match its bare name.
+type AmbiguousProviderError struct {
+	Data    string
+	Options []string // each matching artifact and the tool providing it
+}
+
+func (e *AmbiguousProviderError) Error() string {
+	return fmt.Sprintf("'%s' is provided by more than one tool; use one of: %s",
+		e.Data, strings.Join(e.Options, ", "))
+}
+
+// Dependents returns the tools that @require data, by its full or bare
+// name, sorted by name. It is the reverse of Providers.
+func (r *Registry) Dependents(data string) []*Tool {
+	tools := append([]*Tool(nil), r.requiresIndex[data]...)
+	if i := strings.LastIndex(data, "/"); i != -1 {
+		for _, t := range r.requiresIndex[data[i+1:]] {
+			if !containsTool(tools, t) {
+				tools = append(tools, t)
+			}
+		}
+	}
+	return sortedByName(tools)
+}
+
+// MatchingRequires returns the entries of t's @requires that data
+// answers, in the order they are declared.
+func (t *Tool) MatchingRequires(data string) []string {
+	var reqs []string
+	for _, req := range t.Requires {
+		if ProvidesMatch(data, req) {
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs
+}
+
+// containsTool reports whether tools includes t.
+func containsTool(tools []*Tool, t *Tool) bool {
+	for _, other := range tools {
+		if other == t {
+			return true
+		}
+	}
+	return false
 }
 
 // All returns all tools as a slice.
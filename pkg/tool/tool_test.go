@@ -0,0 +1,399 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindByProvides(t *testing.T) {
+	r := NewRegistry()
+	r.Add(&Tool{Name: "plain", File: "plain.py", Provides: []string{"report"}})
+	r.Add(&Tool{Name: "finance", File: "finance.py", Provides: []string{"finance/report", "finance/ledger"}})
+	r.Add(&Tool{Name: "sales", File: "sales.py", Provides: []string{"sales/report", "sales/ledger"}})
+	r.Add(&Tool{Name: "hr", File: "hr.py", Provides: []string{"hr/staff"}})
+
+	tests := []struct {
+		data      string
+		want      string // tool name; "" for none
+		ambiguous bool
+	}{
+		{data: "report", want: "plain"},
+		{data: "finance/report", want: "finance"},
+		{data: "staff", want: "hr"},
+		{data: "hr/staff", want: "hr"},
+		{data: "ledger", ambiguous: true},
+		{data: "missing"},
+		{data: "other/report"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			got, err := r.FindByProvides(tt.data)
+			var ambiguous *AmbiguousProviderError
+			if tt.ambiguous {
+				if !errors.As(err, &ambiguous) {
+					t.Fatalf("err = %v, want an *AmbiguousProviderError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			name := ""
+			if got != nil {
+				name = got.Name
+			}
+			if name != tt.want {
+				t.Errorf("provider = %q, want %q", name, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindByProvidesAmbiguousOptions(t *testing.T) {
+	r := NewRegistry()
+	r.Add(&Tool{Name: "a", File: "a.py", Provides: []string{"report"}})
+	r.Add(&Tool{Name: "b", File: "b.py", Provides: []string{"report"}})
+	r.Add(&Tool{Name: "c", File: "c.py", Provides: []string{"finance/report"}})
+
+	_, err := r.FindByProvides("report")
+	var ambiguous *AmbiguousProviderError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("err = %v, want an *AmbiguousProviderError", err)
+	}
+	// Only the exact providers are in the running; finance/report isn't
+	want := []string{"report (a)", "report (b)"}
+	if len(ambiguous.Options) != len(want) {
+		t.Fatalf("options = %v, want %v", ambiguous.Options, want)
+	}
+	for i := range want {
+		if ambiguous.Options[i] != want[i] {
+			t.Errorf("options = %v, want %v", ambiguous.Options, want)
+		}
+	}
+}
+
+func TestRemoveByFileRestoresShadowed(t *testing.T) {
+	r := NewRegistry()
+	older := &Tool{Name: "report", File: "a/report.py", Provides: []string{"old-data"}}
+	newer := &Tool{Name: "report", File: "b/report.py", Provides: []string{"new-data"}}
+	r.Add(older)
+	r.Add(newer)
+
+	if r.Get("report") != newer {
+		t.Fatal("the later Add should win")
+	}
+	if got := r.Shadowed["report"]; len(got) != 1 || got[0] != older {
+		t.Fatalf("Shadowed = %v, want the older tool", got)
+	}
+
+	r.RemoveByFile(newer.File)
+
+	if r.Get("report") != older {
+		t.Fatalf("Get = %v, want the shadowed tool restored", r.Get("report"))
+	}
+	if _, ok := r.Shadowed["report"]; ok {
+		t.Errorf("Shadowed still has an entry for report: %v", r.Shadowed["report"])
+	}
+	if p, _ := r.FindByProvides("old-data"); p != older {
+		t.Errorf("FindByProvides(old-data) = %v, want the restored tool", p)
+	}
+	if p, _ := r.FindByProvides("new-data"); p != nil {
+		t.Errorf("FindByProvides(new-data) = %v, want nil after removal", p)
+	}
+}
+
+func TestRemoveByFileDropsShadowed(t *testing.T) {
+	r := NewRegistry()
+	older := &Tool{Name: "report", File: "a/report.py"}
+	newer := &Tool{Name: "report", File: "b/report.py"}
+	r.Add(older)
+	r.Add(newer)
+
+	// Removing the shadowed file leaves the winner alone
+	r.RemoveByFile(older.File)
+
+	if r.Get("report") != newer {
+		t.Errorf("Get = %v, want the winner kept", r.Get("report"))
+	}
+	if _, ok := r.Shadowed["report"]; ok {
+		t.Errorf("Shadowed = %v, want no entry", r.Shadowed["report"])
+	}
+
+	// With nothing left to restore, removing the winner removes the name
+	r.RemoveByFile(newer.File)
+	if r.Get("report") != nil {
+		t.Errorf("Get = %v, want nil", r.Get("report"))
+	}
+}
+
+func TestRemove(t *testing.T) {
+	r := NewRegistry()
+	r.Add(&Tool{Name: "report", File: "a/report.py"})
+	r.Add(&Tool{Name: "report", File: "b/report.py", Provides: []string{"report"}})
+
+	// Unknown names are ignored
+	r.Remove("missing")
+	if r.Get("report") == nil {
+		t.Fatal("removing an unknown name removed another tool")
+	}
+
+	r.Remove("report")
+	if r.Get("report") != nil || len(r.Shadowed) != 0 {
+		t.Fatalf("Remove left %v and shadowed %v", r.Get("report"), r.Shadowed)
+	}
+	if p, _ := r.FindByProvides("report"); p != nil {
+		t.Errorf("FindByProvides = %v after Remove, want nil", p)
+	}
+
+	// The name can be added again
+	again := &Tool{Name: "report", File: "c/report.py"}
+	r.Add(again)
+	if r.Get("report") != again {
+		t.Errorf("Get = %v after re-adding, want the new tool", r.Get("report"))
+	}
+	if len(r.Shadowed) != 0 {
+		t.Errorf("Shadowed = %v, want nothing after re-adding", r.Shadowed)
+	}
+}
+
+func TestAddRenamedTool(t *testing.T) {
+	r := NewRegistry()
+	r.Add(&Tool{Name: "old-name", File: "a/tool.py"})
+	r.Add(&Tool{Name: "new-name", File: "a/tool.py"})
+
+	if r.Get("old-name") != nil {
+		t.Error("re-adding a file under a new @tool name left the old name behind")
+	}
+	if r.Get("new-name") == nil {
+		t.Error("the renamed tool is missing")
+	}
+	if len(r.Shadowed) != 0 {
+		t.Errorf("Shadowed = %v, want nothing", r.Shadowed)
+	}
+}
+
+func TestOutputCollisions(t *testing.T) {
+	abs := filepath.Join(string(filepath.Separator), "srv", "data", "prices.csv")
+	tests := []struct {
+		name  string
+		tools []*Tool
+		base  string
+		want  map[string][]string // resolved path -> tool names
+	}{
+		{
+			name: "same relative output in one source",
+			tools: []*Tool{
+				{Name: "a", File: "/src/tools/a.py", Output: "data/prices.csv"},
+				{Name: "b", File: "/src/tools/b.py", Output: "data/prices.csv"},
+			},
+			want: map[string][]string{"/src/data/prices.csv": {"a", "b"}},
+		},
+		{
+			name: "same relative output in different sources",
+			tools: []*Tool{
+				{Name: "a", File: "/one/tools/a.py", Output: "data/prices.csv"},
+				{Name: "b", File: "/two/tools/b.py", Output: "data/prices.csv"},
+			},
+			want: map[string][]string{},
+		},
+		{
+			name: "relative output resolving to an absolute one",
+			tools: []*Tool{
+				{Name: "a", File: "/srv/tools/a.py", Output: "data/prices.csv"},
+				{Name: "b", File: "/elsewhere/b.py", Output: abs},
+			},
+			want: map[string][]string{abs: {"a", "b"}},
+		},
+		{
+			name: "unclean paths",
+			tools: []*Tool{
+				{Name: "a", File: "/src/tools/a.py", Output: "data/./prices.csv"},
+				{Name: "b", File: "/src/tools/b.py", Output: "data/x/../prices.csv"},
+			},
+			want: map[string][]string{"/src/data/prices.csv": {"a", "b"}},
+		},
+		{
+			name: "output dir joins different sources",
+			tools: []*Tool{
+				{Name: "a", File: "/one/tools/a.py", Output: "data/prices.csv"},
+				{Name: "b", File: "/two/tools/b.py", Output: "data/prices.csv"},
+				{Name: "c", File: "/two/tools/c.py", Output: "/abs/c.csv"},
+			},
+			base: "/ci",
+			want: map[string][]string{"/ci/data/prices.csv": {"a", "b"}},
+		},
+		{
+			name: "same glob",
+			tools: []*Tool{
+				{Name: "a", File: "/src/tools/a.py", Output: "data/report-*.csv"},
+				{Name: "b", File: "/src/tools/b.py", Output: "data/report-*.csv"},
+			},
+			want: map[string][]string{"/src/data/report-*.csv": {"a", "b"}},
+		},
+		{
+			name: "glob and a path it matches",
+			tools: []*Tool{
+				{Name: "a", File: "/src/tools/a.py", Output: "data/report-*.csv"},
+				{Name: "b", File: "/src/tools/b.py", Output: "data/report-2024.csv"},
+				{Name: "c", File: "/src/tools/c.py", Output: "data/summary.csv"},
+			},
+			want: map[string][]string{"/src/data/report-*.csv": {"a", "b"}},
+		},
+		{
+			name: "no outputs",
+			tools: []*Tool{
+				{Name: "a", File: "/src/tools/a.py"},
+				{Name: "b", File: "/src/tools/b.py"},
+			},
+			want: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OutputCollisions(tt.tools, filepath.FromSlash(tt.base))
+			if len(got) != len(tt.want) {
+				t.Fatalf("collisions = %v, want %v", collisionNames(got), tt.want)
+			}
+			for path, names := range tt.want {
+				writers := got[filepath.FromSlash(path)]
+				if len(writers) != len(names) {
+					t.Fatalf("collisions = %v, want %v", collisionNames(got), tt.want)
+				}
+				for i, name := range names {
+					if writers[i].Name != name {
+						t.Errorf("writers of %s = %v, want %v", path, collisionNames(got)[path], names)
+					}
+				}
+			}
+		})
+	}
+}
+
+// collisionNames returns the tool names in collisions, for messages.
+func collisionNames(collisions map[string][]*Tool) map[string][]string {
+	names := make(map[string][]string)
+	for path, tools := range collisions {
+		for _, t := range tools {
+			names[path] = append(names[path], t.Name)
+		}
+	}
+	return names
+}
+
+// checkIndexes fails t unless r's indexes match indexes rebuilt from
+// scratch out of r.Tools.
+func checkIndexes(t *testing.T, r *Registry) {
+	t.Helper()
+	fresh := NewRegistry()
+	for _, tl := range r.Tools {
+		fresh.index(tl)
+	}
+
+	indexes := []struct {
+		name      string
+		got, want map[string][]*Tool
+	}{
+		{"provides", r.providesIndex, fresh.providesIndex},
+		{"keyword", r.keywordIndex, fresh.keywordIndex},
+		{"requires", r.requiresIndex, fresh.requiresIndex},
+	}
+	for _, idx := range indexes {
+		if len(idx.got) != len(idx.want) {
+			t.Errorf("%s index has keys %v, want %v", idx.name, indexKeys(idx.got), indexKeys(idx.want))
+			continue
+		}
+		for key, want := range idx.want {
+			got := sortedByName(idx.got[key])
+			want = sortedByName(want)
+			if len(got) != len(want) {
+				t.Errorf("%s index[%s] has %d tools, want %d", idx.name, key, len(got), len(want))
+				continue
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("%s index[%s][%d] = %s, want %s", idx.name, key, i, got[i].Name, want[i].Name)
+				}
+			}
+		}
+	}
+}
+
+// indexKeys returns index's keys, for messages.
+func indexKeys(index map[string][]*Tool) []string {
+	var keys []string
+	for key := range index {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestIndexesAfterRemove(t *testing.T) {
+	r := NewRegistry()
+	r.Add(&Tool{Name: "prices", File: "a/prices.py", Provides: []string{"finance/prices"}, Keywords: []string{"stocks"}})
+	r.Add(&Tool{Name: "report", File: "a/report.py", Provides: []string{"report"}, Requires: []string{"prices"}, Keywords: []string{"stocks", "pdf"}})
+	r.Add(&Tool{Name: "report", File: "b/report.py", Provides: []string{"summary"}, Keywords: []string{"pdf"}})
+	checkIndexes(t, r)
+
+	r.RemoveByFile("b/report.py") // restores a/report.py
+	checkIndexes(t, r)
+
+	r.Remove("report")
+	checkIndexes(t, r)
+	if got := r.WithKeyword("pdf"); len(got) != 0 {
+		t.Errorf("WithKeyword(pdf) = %v after Remove, want none", got)
+	}
+	if got := r.Dependents("prices"); len(got) != 0 {
+		t.Errorf("Dependents(prices) = %v after Remove, want none", got)
+	}
+
+	r.Remove("prices")
+	checkIndexes(t, r)
+	if len(r.providesIndex) != 0 || len(r.keywordIndex) != 0 || len(r.requiresIndex) != 0 {
+		t.Error("indexes of an empty registry should be empty")
+	}
+}
+
+// benchmarkTools is the size of the registry BenchmarkFindByProvides
+// searches.
+const benchmarkTools = 1000
+
+func BenchmarkFindByProvides(b *testing.B) {
+	r := NewRegistry()
+	for i := 0; i < benchmarkTools; i++ {
+		r.Add(&Tool{
+			Name:     fmt.Sprintf("tool-%d", i),
+			File:     fmt.Sprintf("tools/tool_%d.py", i),
+			Provides: []string{fmt.Sprintf("team-%d/data-%d", i%10, i)},
+		})
+	}
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if t, err := r.FindByProvides(fmt.Sprintf("data-%d", i%benchmarkTools)); t == nil || err != nil {
+				b.Fatalf("FindByProvides = %v, %v", t, err)
+			}
+		}
+	})
+
+	// The scan over every tool that the index replaced, for comparison
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			data := fmt.Sprintf("data-%d", i%benchmarkTools)
+			var found *Tool
+			for _, t := range r.Tools {
+				for _, p := range t.Provides {
+					if ProvidesMatch(p, data) {
+						found = t
+					}
+				}
+			}
+			if found == nil {
+				b.Fatalf("no provider of %s", data)
+			}
+		}
+	})
+}
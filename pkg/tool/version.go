@@ -0,0 +1,98 @@
+package tool
+
+import (
+	"strconv"
+	"strings"
+)
+
+// versionOps are the comparison operators accepted in @requires entries,
+// longest first so "<=" is matched before "<".
+var versionOps = []string{">=", "<=", "==", ">", "<", "="}
+
+// Requirement is a @requires entry with an optional version constraint,
+// e.g. "prices>=1.2" means the provider of prices must be version 1.2 or newer.
+type Requirement struct {
+	Data    string `yaml:"data" json:"data"`
+	Op      string `yaml:"op,omitempty" json:"op,omitempty"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+}
+
+// ParseRequirement parses a @requires entry such as "prices" or "prices>=1.2".
+func ParseRequirement(s string) Requirement {
+	for _, op := range versionOps {
+		if idx := strings.Index(s, op); idx > 0 {
+			return Requirement{
+				Data:    strings.TrimSpace(s[:idx]),
+				Op:      op,
+				Version: strings.TrimSpace(s[idx+len(op):]),
+			}
+		}
+	}
+	return Requirement{Data: s}
+}
+
+// String returns the requirement in @requires syntax.
+func (r Requirement) String() string {
+	return r.Data + r.Op + r.Version
+}
+
+// SatisfiedBy reports whether a provider at the given version meets the
+// constraint. A requirement without a constraint is satisfied by any
+// version; one with a constraint is never satisfied by an empty version.
+func (r Requirement) SatisfiedBy(version string) bool {
+	if r.Op == "" {
+		return true
+	}
+	if version == "" {
+		return false
+	}
+
+	cmp := CompareVersions(version, r.Version)
+	switch r.Op {
+	case ">=":
+		return cmp >= 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case "<":
+		return cmp < 0
+	default: // "==", "="
+		return cmp == 0
+	}
+}
+
+// CompareVersions compares two dotted versions like "1.2" and "1.10.0".
+// Missing components count as zero, a leading "v" is ignored, and
+// non-numeric components are compared as strings.
+// Returns -1, 0, or 1.
+func CompareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+
+		xn, xerr := strconv.Atoi(x)
+		yn, yerr := strconv.Atoi(y)
+		if xerr == nil && yerr == nil {
+			if xn != yn {
+				if xn < yn {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+		if c := strings.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}